@@ -81,6 +81,10 @@ func requiredChecker(v interface{}) error {
 	// Ignore performance, tmp for now
 	// ex: beggo: https://github.com/astaxie/beego/blob/master/validation/validators.go#L95
 	debugf("RequiredChecker %#v", v)
+	if v == nil {
+		return ErrRequired
+	}
+
 	if reflect.DeepEqual(v, reflect.Zero(reflect.TypeOf(v)).Interface()) {
 		return ErrRequired
 	}
@@ -0,0 +1,258 @@
+package validation
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Checksum checker names
+const (
+	CreditCardKey = "credit_card"
+	IBANKey       = "iban"
+	ISBN10Key     = "isbn10"
+	ISBN13Key     = "isbn13"
+	EAN13Key      = "ean13"
+	ISSNKey       = "issn"
+)
+
+// Card brands return by CardBrand
+const (
+	BrandVisa       = "Visa"
+	BrandMasterCard = "MasterCard"
+	BrandAmex       = "American Express"
+	BrandDiscover   = "Discover"
+	BrandDiners     = "Diners Club"
+	BrandJCB        = "JCB"
+)
+
+var (
+	// Card brand by number prefix, longer prefix first
+	cardBrands = []struct {
+		prefixes []string
+		brand    string
+	}{
+		{[]string{"6011", "65"}, BrandDiscover},
+		{[]string{"2131", "1800", "35"}, BrandJCB},
+		{[]string{"34", "37"}, BrandAmex},
+		{[]string{"300", "301", "302", "303", "304", "305", "36", "38"}, BrandDiners},
+		{[]string{"51", "52", "53", "54", "55"}, BrandMasterCard},
+		{[]string{"4"}, BrandVisa},
+	}
+
+	// IBAN length by country, from SWIFT IBAN registry
+	ibanLengths = map[string]int{
+		"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+		"BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24,
+		"DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18,
+		"FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27,
+		"GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+		"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20,
+		"LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27,
+		"MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24, "PL": 28,
+		"PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33, "SA": 24, "SC": 31,
+		"SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+		"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+	}
+)
+
+// Remove space and hyphen used for readability
+func stripSeparators(str string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(str)
+}
+
+// CardBrand return brand of card number by prefix, empty if unknown
+func CardBrand(number string) string {
+	number = stripSeparators(number)
+	for _, b := range cardBrands {
+		for _, prefix := range b.prefixes {
+			if strings.HasPrefix(number, prefix) {
+				return b.brand
+			}
+		}
+	}
+
+	return ""
+}
+
+// Luhn mod 10 checksum, digits only
+func luhn(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+func creditCardChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	number := stripSeparators(str)
+	brand := CardBrand(number)
+
+	if !rxCreditCard.MatchString(number) {
+		return &ErrBadCreditCard{Brand: brand, Reason: "bad prefix or length"}
+	}
+
+	if !luhn(number) {
+		return &ErrBadCreditCard{Brand: brand, Reason: "checksum failed"}
+	}
+
+	return nil
+}
+
+func ibanChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	iban := strings.ToUpper(strings.Replace(str, " ", "", -1))
+	if len(iban) < 4 {
+		return &ErrBadIdentifier{Kind: IBANKey, Reason: "too short"}
+	}
+
+	country := iban[:2]
+	length, ok := ibanLengths[country]
+	if !ok {
+		return &ErrBadIdentifier{Kind: IBANKey, Reason: fmt.Sprintf("unknown country [%s]", country)}
+	}
+
+	if len(iban) != length {
+		return &ErrBadIdentifier{Kind: IBANKey, Reason: fmt.Sprintf("length for %s should be %d, got %d", country, length, len(iban))}
+	}
+
+	if !isNumeric(iban[2:4]) {
+		return &ErrBadIdentifier{Kind: IBANKey, Reason: "check digits should be numeric"}
+	}
+
+	// Move first 4 chars to end, letters to 10-35, then mod 97 should be 1
+	var digits strings.Builder
+	for _, c := range iban[4:] + iban[:4] {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c >= 'A' && c <= 'Z':
+			fmt.Fprintf(&digits, "%d", c-'A'+10)
+		default:
+			return &ErrBadIdentifier{Kind: IBANKey, Reason: fmt.Sprintf("bad char %q", c)}
+		}
+	}
+
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	if new(big.Int).Mod(n, big.NewInt(97)).Int64() != 1 {
+		return &ErrBadIdentifier{Kind: IBANKey, Reason: "checksum failed"}
+	}
+
+	return nil
+}
+
+// Weighted sum with mod 11 check digit, 'X' is 10 at last position
+func mod11Valid(kind, str string, length int) error {
+	if len(str) != length {
+		return &ErrBadIdentifier{Kind: kind, Reason: fmt.Sprintf("should be %d digits, got %d", length, len(str))}
+	}
+
+	sum := 0
+	for i := 0; i < length; i++ {
+		c := str[i]
+		var d int
+		switch {
+		case c >= '0' && c <= '9':
+			d = int(c - '0')
+		case (c == 'X' || c == 'x') && i == length-1:
+			d = 10
+		default:
+			return &ErrBadIdentifier{Kind: kind, Reason: fmt.Sprintf("bad char %q at position %d", c, i)}
+		}
+		sum += d * (length - i)
+	}
+
+	if sum%11 != 0 {
+		return &ErrBadIdentifier{Kind: kind, Reason: "checksum failed"}
+	}
+
+	return nil
+}
+
+// EAN-13 checksum, weights 1 and 3 in turn
+func ean13Valid(kind, str string) error {
+	if len(str) != 13 || !isNumeric(str) {
+		return &ErrBadIdentifier{Kind: kind, Reason: "should be 13 digits"}
+	}
+
+	sum := 0
+	for i := 0; i < 13; i++ {
+		d := int(str[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+
+	if sum%10 != 0 {
+		return &ErrBadIdentifier{Kind: kind, Reason: "checksum failed"}
+	}
+
+	return nil
+}
+
+func isbn10Checker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	return mod11Valid(ISBN10Key, stripSeparators(str), 10)
+}
+
+func isbn13Checker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	isbn := stripSeparators(str)
+	if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+		return &ErrBadIdentifier{Kind: ISBN13Key, Reason: "prefix should be 978 or 979"}
+	}
+
+	return ean13Valid(ISBN13Key, isbn)
+}
+
+func ean13Checker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	return ean13Valid(EAN13Key, str)
+}
+
+// ISSN "0378-5955", hyphen is optional
+func issnChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	if len(str) == 9 && str[4] == '-' {
+		str = str[:4] + str[5:]
+	}
+
+	// ISSN weights 8..2 then check digit, same as mod 11 with 8 chars
+	return mod11Valid(ISSNKey, str, 8)
+}
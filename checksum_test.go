@@ -0,0 +1,57 @@
+package validation
+
+import "testing"
+
+func TestChecksumCheckers(t *testing.T) {
+	tests := []struct {
+		Checker ValidaterFunc
+		Value   string
+		Expect  bool
+	}{
+		{creditCardChecker, "4111111111111111", true},
+		{creditCardChecker, "4111 1111 1111 1111", true},
+		{creditCardChecker, "4111111111111112", false},
+		{creditCardChecker, "378282246310005", true},
+		{creditCardChecker, "5555555555554444", true},
+		{creditCardChecker, "1234", false},
+		{ibanChecker, "DE89 3704 0044 0532 0130 00", true},
+		{ibanChecker, "GB82WEST12345698765432", true},
+		{ibanChecker, "GB82WEST12345698765431", false},
+		{ibanChecker, "DE89370400440532013", false},
+		{ibanChecker, "ZZ89370400440532013000", false},
+		{isbn10Checker, "0-306-40615-2", true},
+		{isbn10Checker, "080442957X", true},
+		{isbn10Checker, "0306406153", false},
+		{isbn13Checker, "978-0-306-40615-7", true},
+		{isbn13Checker, "9780306406158", false},
+		{isbn13Checker, "4006381333931", false},
+		{ean13Checker, "4006381333931", true},
+		{ean13Checker, "4006381333932", false},
+		{issnChecker, "0378-5955", true},
+		{issnChecker, "2049-3630", true},
+		{issnChecker, "0378-5954", false},
+	}
+
+	for i, test := range tests {
+		err := test.Checker(test.Value)
+		if (err == nil) != test.Expect {
+			t.Errorf("case %d [%s] should [%t], got err %v", i, test.Value, test.Expect, err)
+		}
+	}
+}
+
+func TestCreditCardBrand(t *testing.T) {
+	err := creditCardChecker("4111111111111112")
+	bad, ok := err.(*ErrBadCreditCard)
+	if !ok || bad.Brand != BrandVisa {
+		t.Fatalf("should got Visa ErrBadCreditCard, but got %v", err)
+	}
+
+	if bad.Error() != "Visa number is invalid: checksum failed" {
+		t.Errorf("got unexpected message %s", bad.Error())
+	}
+
+	if brand := CardBrand("3782 822463 10005"); brand != BrandAmex {
+		t.Errorf("CardBrand should be %s, got %s", BrandAmex, brand)
+	}
+}
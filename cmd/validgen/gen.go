@@ -0,0 +1,706 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DavadDi/validation"
+)
+
+const validationPath = "github.com/DavadDi/validation"
+
+// Load and type check package in dir, generated output is skipped, so old
+// methods don't conflict. Type errors are ignored, fields of unknown types
+// fall back to reflection.
+func loadPackage(dir, output string) (*types.Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := filepath.Base(output)
+	if output == "" {
+		skip = bp.Name + "_valid.go"
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		if name == skip {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {},
+	}
+
+	path := bp.ImportPath
+	if path == "" || path == "." {
+		path = bp.Name
+	}
+
+	pkg, _ := conf.Check(path, fset, files, nil)
+	return pkg, nil
+}
+
+// generator state of one output file
+type generator struct {
+	pkg     *types.Package
+	buf     bytes.Buffer
+	named   map[*types.TypeName]bool // types with generated methods
+	rules   []string                 // tags of rule vars in order
+	ruleVar map[string]string        // tag to rule var name
+	imports map[string]bool
+	loop    int // depth of loops, for loop var names
+}
+
+// Return source of Validate methods for named types, or all structs with
+// valid tags if names is empty
+func generate(pkg *types.Package, names []string) ([]byte, error) {
+	g := &generator{
+		pkg:     pkg,
+		named:   make(map[*types.TypeName]bool),
+		ruleVar: make(map[string]string),
+		imports: map[string]bool{validationPath: true},
+	}
+
+	var objs []*types.TypeName
+	if len(names) == 0 {
+		for _, name := range pkg.Scope().Names() {
+			obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+			if ok && !obj.IsAlias() && hasValidTag(obj.Type(), make(map[types.Type]bool)) {
+				objs = append(objs, obj)
+			}
+		}
+	}
+
+	for _, name := range names {
+		obj, ok := pkg.Scope().Lookup(strings.TrimSpace(name)).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("type [%s] not found in package %s", name, pkg.Name())
+		}
+
+		if _, ok := obj.Type().Underlying().(*types.Struct); !ok {
+			return nil, fmt.Errorf("type [%s] is not struct", name)
+		}
+		objs = append(objs, obj)
+	}
+
+	for _, obj := range objs {
+		g.named[obj] = true
+	}
+
+	for _, obj := range objs {
+		if err := g.genType(obj); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.source()
+}
+
+// Return true if struct or its embedded structs have valid tag
+func hasValidTag(t types.Type, visiting map[types.Type]bool) bool {
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok || visiting[t] {
+		return false
+	}
+	visiting[t] = true
+
+	for i := 0; i < st.NumFields(); i++ {
+		if _, ok := reflect.StructTag(st.Tag(i)).Lookup(validation.ValidTag); ok {
+			return true
+		}
+
+		if st.Field(i).Embedded() && hasValidTag(deref(st.Field(i).Type()), visiting) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func deref(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+
+	return t
+}
+
+// Return file with imports and rule vars
+func (g *generator) source() ([]byte, error) {
+	var out bytes.Buffer
+
+	fmt.Fprintf(&out, "// Code generated by validgen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", g.pkg.Name())
+
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// Standard packages first, then validation
+	fmt.Fprintf(&out, "import (\n")
+	for _, path := range paths {
+		if path != validationPath {
+			fmt.Fprintf(&out, "%q\n", path)
+		}
+	}
+	if len(paths) > 1 {
+		fmt.Fprintf(&out, "\n")
+	}
+	fmt.Fprintf(&out, "%q\n)\n\n", validationPath)
+
+	if len(g.rules) > 0 {
+		fmt.Fprintf(&out, "var (\n")
+		for _, tag := range g.rules {
+			fmt.Fprintf(&out, "%s = validation.NewRule(%q)\n", g.ruleVar[tag], tag)
+		}
+		fmt.Fprintf(&out, ")\n")
+	}
+
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %s\n%s", err, out.Bytes())
+	}
+
+	return src, nil
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// Return var name of compiled rule
+func (g *generator) rule(name, param string) string {
+	tag := name
+	if param != "" {
+		tag += validation.ParamSeparator + param
+	}
+
+	if v, ok := g.ruleVar[tag]; ok {
+		return v
+	}
+
+	v := fmt.Sprintf("validgenRule%d", len(g.rules))
+	g.ruleVar[tag] = v
+	g.rules = append(g.rules, tag)
+
+	return v
+}
+
+// Join rules to tag, without required if ignoreRequired
+func joinTag(required, ignoreRequired bool, rules []validation.TagRule) string {
+	var parts []string
+	if required && !ignoreRequired {
+		parts = append(parts, validation.RequiredKey)
+	}
+
+	for _, r := range rules {
+		if r.Param != "" {
+			parts = append(parts, r.Name+validation.ParamSeparator+r.Param)
+		} else {
+			parts = append(parts, r.Name)
+		}
+	}
+
+	return strings.Join(parts, validation.FuncSeparator)
+}
+
+// visField visible field of struct, same as reflect.VisibleFields
+type visField struct {
+	v      *types.Var
+	tag    reflect.StructTag
+	depth  int
+	parent *visField // embedded field it's promoted from
+	hidden bool
+}
+
+// fieldWalker walk struct like reflect.VisibleFields
+type fieldWalker struct {
+	fields   []*visField
+	byName   map[string]int
+	visiting map[types.Type]bool
+}
+
+func (w *fieldWalker) walk(t types.Type, parent *visField, depth int) {
+	if w.visiting[t] {
+		return
+	}
+	w.visiting[t] = true
+
+	st := t.Underlying().(*types.Struct)
+	for i := 0; i < st.NumFields(); i++ {
+		f := &visField{v: st.Field(i), tag: reflect.StructTag(st.Tag(i)), depth: depth, parent: parent}
+
+		add := true
+		if old, ok := w.byName[f.v.Name()]; ok {
+			o := w.fields[old]
+			switch {
+			case depth == o.depth:
+				// Same name at same depth cancel each other
+				o.hidden = true
+				add = false
+			case depth < o.depth:
+				o.hidden = true
+			default:
+				add = false
+			}
+		}
+
+		if add {
+			w.byName[f.v.Name()] = len(w.fields)
+			w.fields = append(w.fields, f)
+		}
+
+		if f.v.Embedded() {
+			if _, ok := deref(f.v.Type()).Underlying().(*types.Struct); ok {
+				w.walk(deref(f.v.Type()), f, depth+1)
+			}
+		}
+	}
+
+	delete(w.visiting, t)
+}
+
+func visibleFields(t types.Type) []*visField {
+	w := &fieldWalker{byName: make(map[string]int), visiting: make(map[types.Type]bool)}
+	w.walk(t, nil, 0)
+
+	var out []*visField
+	for _, f := range w.fields {
+		if !f.hidden {
+			out = append(out, f)
+		}
+	}
+
+	return out
+}
+
+// Return tag of field with override of embedded fields, false if any
+// embedded field on the way has valid:"-"
+func promotedTag(f *visField) (string, bool) {
+	tag := f.tag.Get(validation.ValidTag)
+	for p := f.parent; p != nil; p = p.parent {
+		if p.tag.Get(validation.ValidTag) == validation.ValidIgnor {
+			return "", false
+		}
+
+		if o, ok := p.tag.Lookup(validation.ValidTag + "." + f.v.Name()); ok {
+			tag = o
+		}
+	}
+
+	return tag, true
+}
+
+// Return access expression and nil checks of embedded ptrs on the way
+func fieldExpr(f *visField) (string, []string) {
+	var chain []*visField
+	for p := f; p != nil; p = p.parent {
+		chain = append([]*visField{p}, chain...)
+	}
+
+	x := "v"
+	var guards []string
+	for i, p := range chain {
+		x += "." + p.v.Name()
+		if i < len(chain)-1 {
+			if _, ok := p.v.Type().(*types.Pointer); ok {
+				guards = append(guards, x+" != nil")
+			}
+		}
+	}
+
+	return x, guards
+}
+
+// Return true if method is a hook, other signature is not called by Validate
+// because type assertion to hook interface fails
+func isHook(sel *types.Selection, sig func(*types.Signature) bool) bool {
+	if sel == nil {
+		return false
+	}
+
+	s, ok := sel.Obj().Type().(*types.Signature)
+	return ok && sig(s)
+}
+
+// Validater() error
+func isValidaterSig(s *types.Signature) bool {
+	return s.Params().Len() == 0 && s.Results().Len() == 1 &&
+		types.Identical(s.Results().At(0).Type(), types.Universe.Lookup("error").Type())
+}
+
+// ValidateStruct(validation.Reporter)
+func isStructValidaterSig(s *types.Signature) bool {
+	if s.Params().Len() != 1 || s.Results().Len() != 0 {
+		return false
+	}
+
+	n, ok := s.Params().At(0).Type().(*types.Named)
+	return ok && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == validationPath && n.Obj().Name() == "Reporter"
+}
+
+// Embedded struct or struct ptr, fields are promoted
+func isEmbeddedStruct(f *types.Var) bool {
+	t := deref(f.Type())
+	_, ok := t.Underlying().(*types.Struct)
+	return f.Embedded() && ok && !isTimeType(t)
+}
+
+func isTimeType(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	return ok && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == "time" && n.Obj().Name() == "Time"
+}
+
+// fieldSpec rules of one field
+type fieldSpec struct {
+	name     string // error field name
+	required bool
+	rules    []validation.TagRule
+	owner    types.Type // struct of field, for cross field rules
+}
+
+func (g *generator) genType(obj *types.TypeName) error {
+	t := obj.Type()
+	name := obj.Name()
+
+	// Old output is not loaded, so these are written by user
+	for _, m := range []string{"Validate", "ValidgenGenerated", "validateFields"} {
+		if o, _, _ := types.LookupFieldOrMethod(t, true, g.pkg, m); o != nil {
+			return fmt.Errorf("type [%s] already has %s", name, m)
+		}
+	}
+
+	g.printf("\n// Validate check %s by valid tags, errors are validation.Errors\n", name)
+	g.printf("func (v *%s) Validate() error {\n", name)
+	g.printf("var errs validation.Errors\n")
+	g.printf("v.validateFields(&errs)\n")
+	g.printf("if len(errs) > 0 {\nreturn errs\n}\n\nreturn nil\n}\n")
+
+	g.printf("\n// ValidgenGenerated mark Validate of %s as generated, see validation.GeneratedValidater\n", name)
+	g.printf("func (v *%s) ValidgenGenerated() {}\n", name)
+
+	g.printf("\n// validateFields append errors of hooks and fields of %s to errs\n", name)
+	g.printf("func (v *%s) validateFields(errs *validation.Errors) {\n", name)
+
+	mset := types.NewMethodSet(types.NewPointer(t))
+	if isHook(mset.Lookup(g.pkg, "Validater"), isValidaterSig) {
+		g.printf("if err := v.Validater(); err != nil {\n")
+		g.printf("*errs = append(*errs, &validation.Error{FieldName: %q, Value: *v, Err: err})\n}\n", "Object")
+	}
+
+	if isHook(mset.Lookup(g.pkg, "ValidateStruct"), isStructValidaterSig) {
+		g.printf("v.ValidateStruct(validation.NewErrorsReporter(errs, v))\n")
+	}
+
+	for _, f := range visibleFields(t) {
+		if !f.v.Embedded() && !f.v.Exported() {
+			continue
+		}
+
+		tag, ok := promotedTag(f)
+		if !ok {
+			continue
+		}
+
+		trs := validation.ParseTag(tag)
+		if len(trs) == 0 {
+			continue
+		}
+
+		spec := &fieldSpec{name: f.v.Name(), owner: t}
+		for _, tr := range trs {
+			if tr.Name == validation.RequiredKey {
+				spec.required = true
+				continue
+			}
+			spec.rules = append(spec.rules, tr)
+		}
+
+		x, guards := fieldExpr(f)
+		if len(guards) > 0 {
+			g.printf("if %s {\n", strings.Join(guards, " && "))
+		}
+
+		if isEmbeddedStruct(f.v) {
+			// Only required for embedded, unexported one can't be checked
+			if spec.required && f.v.Exported() {
+				g.required(spec.name, x, f.v.Type())
+			}
+		} else {
+			g.typeCheck(spec, x, f.v.Type(), false)
+		}
+
+		if len(guards) > 0 {
+			g.printf("}\n")
+		}
+	}
+
+	g.printf("}\n")
+
+	return nil
+}
+
+func (g *generator) appendErr(name, x, err string) {
+	g.printf("*errs = append(*errs, &validation.Error{FieldName: %q, Value: %s, Err: %s})\n", name, x, err)
+}
+
+// Kinds of field types
+const (
+	kindScalar = iota
+	kindPointer
+	kindList
+	kindStruct
+	kindOther // checked by reflection
+)
+
+func classify(t types.Type) int {
+	if isTimeType(t) {
+		return kindScalar
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		if u.Info()&(types.IsBoolean|types.IsInteger|types.IsFloat|types.IsString) != 0 && u.Kind() != types.UnsafePointer {
+			return kindScalar
+		}
+	case *types.Pointer:
+		return kindPointer
+	case *types.Slice, *types.Array:
+		return kindList
+	case *types.Struct:
+		return kindStruct
+	}
+
+	return kindOther
+}
+
+// Same as typeCheck of validation, x is expression of value
+func (g *generator) typeCheck(spec *fieldSpec, x string, t types.Type, ignoreRequired bool) {
+	kind := classify(t)
+	if kind == kindOther || !g.crossOK(spec) {
+		g.fallback(spec, x, ignoreRequired)
+		return
+	}
+
+	if spec.required && !ignoreRequired {
+		g.required(spec.name, x, t)
+	}
+
+	switch kind {
+	case kindScalar:
+		g.checkRules(spec, x, t)
+
+	case kindPointer:
+		// Skip empty block, such as required only
+		outer := g.buf
+		g.buf = bytes.Buffer{}
+		g.typeCheck(spec, "(*"+x+")", t.Underlying().(*types.Pointer).Elem(), true)
+		inner := g.buf
+		g.buf = outer
+
+		if inner.Len() > 0 {
+			g.printf("if %s != nil {\n%s}\n", x, inner.Bytes())
+		}
+
+	case kindList:
+		var elem types.Type
+		switch u := t.Underlying().(type) {
+		case *types.Slice:
+			elem = u.Elem()
+		case *types.Array:
+			elem = u.Elem()
+		}
+
+		i := fmt.Sprintf("i%d", g.loop)
+		g.loop++
+		g.printf("for %s := range %s {\n", i, x)
+
+		e := x + "[" + i + "]"
+		if classify(elem) == kindStruct {
+			g.nested(e, elem)
+		} else {
+			g.typeCheck(spec, e, elem, false)
+		}
+
+		g.printf("}\n")
+		g.loop--
+
+	case kindStruct:
+		g.nested(x, t)
+	}
+}
+
+// Validate nested struct, by generated method or reflection
+func (g *generator) nested(x string, t types.Type) {
+	if n, ok := t.(*types.Named); ok && g.named[n.Obj()] {
+		g.printf("%s.validateFields(errs)\n", x)
+		return
+	}
+
+	g.printf("*errs = append(*errs, validation.StructErrors(&%s)...)\n", x)
+}
+
+// Check field by reflection with same tag
+func (g *generator) fallback(spec *fieldSpec, x string, ignoreRequired bool) {
+	tag := joinTag(spec.required, ignoreRequired, spec.rules)
+	g.printf("*errs = append(*errs, validation.CheckField(%q, %s, %q, v)...)\n", spec.name, x, tag)
+}
+
+// Return true if other fields of cross field rules can be accessed directly
+func (g *generator) crossOK(spec *fieldSpec) bool {
+	for _, r := range spec.rules {
+		if validation.IsCrossRule(r.Name) {
+			if _, ok := g.otherField(spec, r.Param); !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Return expression of other field, false if it can't be accessed directly
+func (g *generator) otherField(spec *fieldSpec, name string) (string, bool) {
+	if name == "" || !token.IsExported(name) {
+		return "", false
+	}
+
+	obj, index, _ := types.LookupFieldOrMethod(spec.owner, false, g.pkg, name)
+	if _, ok := obj.(*types.Var); !ok {
+		return "", false
+	}
+
+	// Promoted by embedded ptr may be nil
+	st := spec.owner.Underlying().(*types.Struct)
+	for _, i := range index[:len(index)-1] {
+		f := st.Field(i)
+		if _, ok := f.Type().(*types.Pointer); ok {
+			return "", false
+		}
+		st = f.Type().Underlying().(*types.Struct)
+	}
+
+	return "v." + name, true
+}
+
+// Inline zero check for required
+func (g *generator) required(name, x string, t types.Type) {
+	var cond string
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			cond = x + ` == ""`
+		case u.Info()&types.IsBoolean != 0:
+			cond = "!" + x
+		case u.Info()&types.IsNumeric != 0:
+			cond = x + " == 0"
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature:
+		cond = x + " == nil"
+	}
+
+	if cond == "" || isTimeType(t) {
+		g.printf("if err := %s.Check(%s, nil); err != nil {\n", g.rule(validation.RequiredKey, ""), x)
+		g.appendErr(name, x, "err")
+		g.printf("}\n")
+		return
+	}
+
+	g.printf("if %s {\n", cond)
+	g.appendErr(name, x, "validation.ErrRequired")
+	g.printf("}\n")
+}
+
+// Check rules on scalar, simple rules of string are inlined
+func (g *generator) checkRules(spec *fieldSpec, x string, t types.Type) {
+	isString := types.Identical(t, types.Typ[types.String])
+
+	for _, r := range spec.rules {
+		if isString && g.inline(spec.name, x, r) {
+			continue
+		}
+
+		other := "nil"
+		if validation.IsCrossRule(r.Name) {
+			other, _ = g.otherField(spec, r.Param)
+		}
+
+		g.printf("if err := %s.Check(%s, %s); err != nil {\n", g.rule(r.Name, r.Param), x, other)
+		g.appendErr(spec.name, x, "err")
+		g.printf("}\n")
+	}
+}
+
+// Inline min_len, max_len and oneof of string, false if not inlined
+func (g *generator) inline(name, x string, r validation.TagRule) bool {
+	switch r.Name {
+	case validation.MinLenKey, validation.MaxLenKey:
+		n, err := strconv.Atoi(r.Param)
+		if err != nil || n < 0 {
+			return false
+		}
+
+		op, key := "<", "validation.MinLenKey"
+		if r.Name == validation.MaxLenKey {
+			op, key = ">", "validation.MaxLenKey"
+		}
+
+		g.imports["unicode/utf8"] = true
+		g.printf("if utf8.RuneCountInString(%s) %s %d {\n", x, op, n)
+		g.appendErr(name, x, fmt.Sprintf("&validation.ErrLength{Rule: %s, Limit: %d}", key, n))
+		g.printf("}\n")
+
+	case validation.OneOfKey:
+		// Duplicated case doesn't compile, first one is kept same as Validate
+		var values []string
+		seen := make(map[string]bool)
+		for _, s := range strings.Split(r.Param, ",") {
+			if s = strings.TrimSpace(s); s != "" && !seen[s] {
+				seen[s] = true
+				values = append(values, strconv.Quote(s))
+			}
+		}
+
+		if len(values) == 0 {
+			return false
+		}
+
+		list := strings.Join(values, ", ")
+		g.printf("switch %s {\ncase %s:\ndefault:\n", x, list)
+		g.appendErr(name, x, fmt.Sprintf("&validation.ErrNotOneOf{Values: []string{%s}}", list))
+		g.printf("}\n")
+
+	default:
+		return false
+	}
+
+	return true
+}
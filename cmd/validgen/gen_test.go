@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	const golden = "internal/sample/sample_valid.go"
+
+	pkg, err := loadPackage("internal/sample", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := generate(pkg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date, run: go run ./cmd/validgen ./cmd/validgen/internal/sample", golden)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	pkg, err := loadPackage("internal/sample", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		names []string
+		err   string
+	}{
+		{[]string{"Missing"}, "Missing"},
+		{[]string{"Level"}, "Level"},
+	}
+
+	for _, tt := range tests {
+		_, err := generate(pkg, tt.names)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("generate(%v) error = %v, want contains %q", tt.names, err, tt.err)
+		}
+	}
+}
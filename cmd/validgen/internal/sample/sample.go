@@ -0,0 +1,105 @@
+// Package sample types for validgen tests, sample_valid.go is generated by
+//
+//	go run ./cmd/validgen ./cmd/validgen/internal/sample
+package sample
+
+import (
+	"errors"
+	"time"
+
+	"github.com/DavadDi/validation"
+)
+
+func init() {
+	validation.AddValidater("even", func(v interface{}) error {
+		if n, ok := v.(int); !ok || n%2 != 0 {
+			return errors.New("should be even")
+		}
+		return nil
+	})
+}
+
+// Level named int
+type Level int
+
+// Base embedded in User
+type Base struct {
+	ID      string `valid:"required;uuid"`
+	Creator string `valid:"required"`
+}
+
+// Audit embedded by ptr
+type Audit struct {
+	By string `valid:"required"`
+}
+
+// Address nested struct
+type Address struct {
+	City string `valid:"required;max_len=8"`
+	Zip  string `valid:"regex=^[0-9]{6}$"`
+}
+
+// Validater hook
+func (a *Address) Validater() error {
+	if a.City == "Nowhere" {
+		return errors.New("city not found")
+	}
+	return nil
+}
+
+// User all kinds of fields
+type User struct {
+	Base `valid.Creator:"-"`
+	*Audit
+
+	Name     string            `valid:"required;min_len=2;max_len=16"`
+	Role     string            `valid:"oneof=admin,user,admin"`
+	Level    Level             `valid:"required;oneof=1,2,3"`
+	Active   bool              `valid:"required"`
+	Score    float64           `valid:"required"`
+	Even     int               `valid:"even"`
+	Email    *string           `valid:"required;email"`
+	Phone    *string           `valid:"e164"`
+	Tags     []string          `valid:"required;regex=^[a-z]+$"`
+	Ptrs     []*int            `valid:"required"`
+	Homes    []Address         `valid:"required"`
+	Work     *Address          `valid:"required"`
+	Office   Address           ``
+	Labels   map[string]string `valid:"max_len=3"`
+	Extra    interface{}       `valid:"required"`
+	Born     time.Time         `valid:"required;past"`
+	Password string            `valid:"required"`
+	Confirm  string            `valid:"eqfield=Password"`
+	Start    int
+	End      int             `valid:"gtfield=Start"`
+	Ref      int             `valid:"eqfield=Missing"`
+	Anon     struct{ X int } `valid:"required"`
+	Skip     string          `valid:"-"`
+	private  string          `valid:"required"`
+}
+
+// Form with StructValidater hook
+type Form struct {
+	Min int `valid:"required"`
+	Max int `valid:"required"`
+}
+
+// ValidateStruct report on fields
+func (f *Form) ValidateStruct(r validation.Reporter) {
+	if f.Min > f.Max {
+		r.Report("Min", errors.New("min should not be more than max"))
+	}
+}
+
+// Odd has methods named as hooks with other signatures, they aren't hooks
+type Odd struct {
+	Name string `valid:"required"`
+}
+
+// Validater result isn't error
+func (o *Odd) Validater() string {
+	return "odd"
+}
+
+// ValidateStruct param isn't validation.Reporter
+func (o *Odd) ValidateStruct(name string) {}
@@ -0,0 +1,133 @@
+package sample
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DavadDi/validation"
+)
+
+// Generated types are marked for httpbind
+var _ validation.GeneratedValidater = (*User)(nil)
+
+func validUser() *User {
+	email := "dave@do1618.com"
+	n := 1
+
+	return &User{
+		Base:     Base{ID: "0b8e1e2a-8f6a-4c5b-9a57-3c8f0d1f9e21", Creator: "dave"},
+		Audit:    &Audit{By: "admin"},
+		Name:     "dave",
+		Role:     "admin",
+		Level:    2,
+		Active:   true,
+		Score:    1.5,
+		Even:     2,
+		Email:    &email,
+		Tags:     []string{"go"},
+		Ptrs:     []*int{&n},
+		Homes:    []Address{{City: "Beijing", Zip: "100000"}},
+		Work:     &Address{City: "Shanghai", Zip: "200000"},
+		Office:   Address{City: "Wuhan", Zip: "430000"},
+		Labels:   map[string]string{"k": "v"},
+		Extra:    1,
+		Born:     time.Now().Add(-time.Hour),
+		Password: "secret",
+		Confirm:  "secret",
+		Start:    1,
+		End:      2,
+		Anon:     struct{ X int }{1},
+	}
+}
+
+// Generated Validate should return same errors as reflection
+func checkSame(t *testing.T, name string, obj interface{}, generated error) {
+	t.Helper()
+
+	mv := validation.NewValidation()
+	mv.Validate(obj)
+	want := mv.Errs()
+
+	var got validation.Errors
+	if generated != nil {
+		got = generated.(validation.Errors)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d errors %v, want %d %v", name, len(got), got, len(want), want)
+	}
+
+	for i := range want {
+		if got[i].FieldName != want[i].FieldName || got[i].Err.Error() != want[i].Err.Error() ||
+			!reflect.DeepEqual(got[i].Value, want[i].Value) {
+			t.Errorf("%s: error %d = %v, want %v", name, i, got[i], want[i])
+		}
+	}
+}
+
+func TestUserSameAsReflection(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(u *User)
+	}{
+		{"valid", func(u *User) {}},
+		{"zero", func(u *User) { *u = User{} }},
+		{"base", func(u *User) { u.ID = "bad"; u.Creator = "" }},
+		{"nil audit", func(u *User) { u.Audit = nil }},
+		{"audit", func(u *User) { u.By = "" }},
+		{"name", func(u *User) { u.Name = "d" }},
+		{"name runes", func(u *User) { u.Name = "数据验证数据验证数据验证数据验证数据验证" }},
+		{"role", func(u *User) { u.Role = "root" }},
+		{"level", func(u *User) { u.Level = 5 }},
+		{"even", func(u *User) { u.Even = 3 }},
+		{"email", func(u *User) { s := "dave"; u.Email = &s }},
+		{"phone", func(u *User) { s := "12345"; u.Phone = &s }},
+		{"tags", func(u *User) { u.Tags = []string{"go", "Go", ""} }},
+		{"ptrs", func(u *User) { u.Ptrs = []*int{nil} }},
+		{"homes", func(u *User) { u.Homes = []Address{{}, {City: "Nowhere", Zip: "1"}} }},
+		{"work", func(u *User) { u.Work = &Address{City: "Nowhere"} }},
+		{"office", func(u *User) { u.Office.City = "Hangzhou city" }},
+		{"labels", func(u *User) { u.Labels = map[string]string{"a": "long", "b": "ok"} }},
+		{"born", func(u *User) { u.Born = time.Now().Add(time.Hour) }},
+		{"confirm", func(u *User) { u.Confirm = "other" }},
+		{"end", func(u *User) { u.End = 0 }},
+		{"ref", func(u *User) { u.Ref = 1 }},
+		{"anon", func(u *User) { u.Anon.X = 0 }},
+	}
+
+	for _, tt := range tests {
+		u := validUser()
+		tt.edit(u)
+		checkSame(t, tt.name, u, u.Validate())
+	}
+}
+
+func TestHooksSameAsReflection(t *testing.T) {
+	forms := []*Form{{Min: 1, Max: 2}, {Min: 3, Max: 2}, {}}
+	for _, f := range forms {
+		checkSame(t, "form", f, f.Validate())
+	}
+
+	addrs := []*Address{{City: "Wuhan"}, {City: "Nowhere"}, {Zip: "abc"}}
+	for _, a := range addrs {
+		checkSame(t, "address", a, a.Validate())
+	}
+
+	odds := []*Odd{{}, {Name: "x"}}
+	for _, o := range odds {
+		checkSame(t, "odd", o, o.Validate())
+	}
+}
+
+func TestValidateNil(t *testing.T) {
+	if err := (&Form{Min: 1, Max: 2}).Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+
+	// Missing field is only reported by Ref
+	errs, _ := validUser().Validate().(validation.Errors)
+	if len(errs) != 1 || errs[0].FieldName != "Ref" {
+		t.Errorf("Validate() = %v, want Ref error", errs)
+	}
+}
@@ -0,0 +1,271 @@
+// Code generated by validgen; DO NOT EDIT.
+
+package sample
+
+import (
+	"unicode/utf8"
+
+	"github.com/DavadDi/validation"
+)
+
+var (
+	validgenRule0  = validation.NewRule("regex=^[0-9]{6}$")
+	validgenRule1  = validation.NewRule("uuid")
+	validgenRule2  = validation.NewRule("oneof=1,2,3")
+	validgenRule3  = validation.NewRule("even")
+	validgenRule4  = validation.NewRule("email")
+	validgenRule5  = validation.NewRule("e164")
+	validgenRule6  = validation.NewRule("regex=^[a-z]+$")
+	validgenRule7  = validation.NewRule("required")
+	validgenRule8  = validation.NewRule("past")
+	validgenRule9  = validation.NewRule("eqfield=Password")
+	validgenRule10 = validation.NewRule("gtfield=Start")
+)
+
+// Validate check Address by valid tags, errors are validation.Errors
+func (v *Address) Validate() error {
+	var errs validation.Errors
+	v.validateFields(&errs)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ValidgenGenerated mark Validate of Address as generated, see validation.GeneratedValidater
+func (v *Address) ValidgenGenerated() {}
+
+// validateFields append errors of hooks and fields of Address to errs
+func (v *Address) validateFields(errs *validation.Errors) {
+	if err := v.Validater(); err != nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Object", Value: *v, Err: err})
+	}
+	if v.City == "" {
+		*errs = append(*errs, &validation.Error{FieldName: "City", Value: v.City, Err: validation.ErrRequired})
+	}
+	if utf8.RuneCountInString(v.City) > 8 {
+		*errs = append(*errs, &validation.Error{FieldName: "City", Value: v.City, Err: &validation.ErrLength{Rule: validation.MaxLenKey, Limit: 8}})
+	}
+	if err := validgenRule0.Check(v.Zip, nil); err != nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Zip", Value: v.Zip, Err: err})
+	}
+}
+
+// Validate check Audit by valid tags, errors are validation.Errors
+func (v *Audit) Validate() error {
+	var errs validation.Errors
+	v.validateFields(&errs)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ValidgenGenerated mark Validate of Audit as generated, see validation.GeneratedValidater
+func (v *Audit) ValidgenGenerated() {}
+
+// validateFields append errors of hooks and fields of Audit to errs
+func (v *Audit) validateFields(errs *validation.Errors) {
+	if v.By == "" {
+		*errs = append(*errs, &validation.Error{FieldName: "By", Value: v.By, Err: validation.ErrRequired})
+	}
+}
+
+// Validate check Base by valid tags, errors are validation.Errors
+func (v *Base) Validate() error {
+	var errs validation.Errors
+	v.validateFields(&errs)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ValidgenGenerated mark Validate of Base as generated, see validation.GeneratedValidater
+func (v *Base) ValidgenGenerated() {}
+
+// validateFields append errors of hooks and fields of Base to errs
+func (v *Base) validateFields(errs *validation.Errors) {
+	if v.ID == "" {
+		*errs = append(*errs, &validation.Error{FieldName: "ID", Value: v.ID, Err: validation.ErrRequired})
+	}
+	if err := validgenRule1.Check(v.ID, nil); err != nil {
+		*errs = append(*errs, &validation.Error{FieldName: "ID", Value: v.ID, Err: err})
+	}
+	if v.Creator == "" {
+		*errs = append(*errs, &validation.Error{FieldName: "Creator", Value: v.Creator, Err: validation.ErrRequired})
+	}
+}
+
+// Validate check Form by valid tags, errors are validation.Errors
+func (v *Form) Validate() error {
+	var errs validation.Errors
+	v.validateFields(&errs)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ValidgenGenerated mark Validate of Form as generated, see validation.GeneratedValidater
+func (v *Form) ValidgenGenerated() {}
+
+// validateFields append errors of hooks and fields of Form to errs
+func (v *Form) validateFields(errs *validation.Errors) {
+	v.ValidateStruct(validation.NewErrorsReporter(errs, v))
+	if v.Min == 0 {
+		*errs = append(*errs, &validation.Error{FieldName: "Min", Value: v.Min, Err: validation.ErrRequired})
+	}
+	if v.Max == 0 {
+		*errs = append(*errs, &validation.Error{FieldName: "Max", Value: v.Max, Err: validation.ErrRequired})
+	}
+}
+
+// Validate check Odd by valid tags, errors are validation.Errors
+func (v *Odd) Validate() error {
+	var errs validation.Errors
+	v.validateFields(&errs)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ValidgenGenerated mark Validate of Odd as generated, see validation.GeneratedValidater
+func (v *Odd) ValidgenGenerated() {}
+
+// validateFields append errors of hooks and fields of Odd to errs
+func (v *Odd) validateFields(errs *validation.Errors) {
+	if v.Name == "" {
+		*errs = append(*errs, &validation.Error{FieldName: "Name", Value: v.Name, Err: validation.ErrRequired})
+	}
+}
+
+// Validate check User by valid tags, errors are validation.Errors
+func (v *User) Validate() error {
+	var errs validation.Errors
+	v.validateFields(&errs)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ValidgenGenerated mark Validate of User as generated, see validation.GeneratedValidater
+func (v *User) ValidgenGenerated() {}
+
+// validateFields append errors of hooks and fields of User to errs
+func (v *User) validateFields(errs *validation.Errors) {
+	if v.Base.ID == "" {
+		*errs = append(*errs, &validation.Error{FieldName: "ID", Value: v.Base.ID, Err: validation.ErrRequired})
+	}
+	if err := validgenRule1.Check(v.Base.ID, nil); err != nil {
+		*errs = append(*errs, &validation.Error{FieldName: "ID", Value: v.Base.ID, Err: err})
+	}
+	if v.Audit != nil {
+		if v.Audit.By == "" {
+			*errs = append(*errs, &validation.Error{FieldName: "By", Value: v.Audit.By, Err: validation.ErrRequired})
+		}
+	}
+	if v.Name == "" {
+		*errs = append(*errs, &validation.Error{FieldName: "Name", Value: v.Name, Err: validation.ErrRequired})
+	}
+	if utf8.RuneCountInString(v.Name) < 2 {
+		*errs = append(*errs, &validation.Error{FieldName: "Name", Value: v.Name, Err: &validation.ErrLength{Rule: validation.MinLenKey, Limit: 2}})
+	}
+	if utf8.RuneCountInString(v.Name) > 16 {
+		*errs = append(*errs, &validation.Error{FieldName: "Name", Value: v.Name, Err: &validation.ErrLength{Rule: validation.MaxLenKey, Limit: 16}})
+	}
+	switch v.Role {
+	case "admin", "user":
+	default:
+		*errs = append(*errs, &validation.Error{FieldName: "Role", Value: v.Role, Err: &validation.ErrNotOneOf{Values: []string{"admin", "user"}}})
+	}
+	if v.Level == 0 {
+		*errs = append(*errs, &validation.Error{FieldName: "Level", Value: v.Level, Err: validation.ErrRequired})
+	}
+	if err := validgenRule2.Check(v.Level, nil); err != nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Level", Value: v.Level, Err: err})
+	}
+	if !v.Active {
+		*errs = append(*errs, &validation.Error{FieldName: "Active", Value: v.Active, Err: validation.ErrRequired})
+	}
+	if v.Score == 0 {
+		*errs = append(*errs, &validation.Error{FieldName: "Score", Value: v.Score, Err: validation.ErrRequired})
+	}
+	if err := validgenRule3.Check(v.Even, nil); err != nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Even", Value: v.Even, Err: err})
+	}
+	if v.Email == nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Email", Value: v.Email, Err: validation.ErrRequired})
+	}
+	if v.Email != nil {
+		if err := validgenRule4.Check((*v.Email), nil); err != nil {
+			*errs = append(*errs, &validation.Error{FieldName: "Email", Value: (*v.Email), Err: err})
+		}
+	}
+	if v.Phone != nil {
+		if err := validgenRule5.Check((*v.Phone), nil); err != nil {
+			*errs = append(*errs, &validation.Error{FieldName: "Phone", Value: (*v.Phone), Err: err})
+		}
+	}
+	if v.Tags == nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Tags", Value: v.Tags, Err: validation.ErrRequired})
+	}
+	for i0 := range v.Tags {
+		if v.Tags[i0] == "" {
+			*errs = append(*errs, &validation.Error{FieldName: "Tags", Value: v.Tags[i0], Err: validation.ErrRequired})
+		}
+		if err := validgenRule6.Check(v.Tags[i0], nil); err != nil {
+			*errs = append(*errs, &validation.Error{FieldName: "Tags", Value: v.Tags[i0], Err: err})
+		}
+	}
+	if v.Ptrs == nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Ptrs", Value: v.Ptrs, Err: validation.ErrRequired})
+	}
+	for i0 := range v.Ptrs {
+		if v.Ptrs[i0] == nil {
+			*errs = append(*errs, &validation.Error{FieldName: "Ptrs", Value: v.Ptrs[i0], Err: validation.ErrRequired})
+		}
+	}
+	if v.Homes == nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Homes", Value: v.Homes, Err: validation.ErrRequired})
+	}
+	for i0 := range v.Homes {
+		v.Homes[i0].validateFields(errs)
+	}
+	if v.Work == nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Work", Value: v.Work, Err: validation.ErrRequired})
+	}
+	if v.Work != nil {
+		(*v.Work).validateFields(errs)
+	}
+	*errs = append(*errs, validation.CheckField("Labels", v.Labels, "max_len=3", v)...)
+	*errs = append(*errs, validation.CheckField("Extra", v.Extra, "required", v)...)
+	if err := validgenRule7.Check(v.Born, nil); err != nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Born", Value: v.Born, Err: err})
+	}
+	if err := validgenRule8.Check(v.Born, nil); err != nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Born", Value: v.Born, Err: err})
+	}
+	if v.Password == "" {
+		*errs = append(*errs, &validation.Error{FieldName: "Password", Value: v.Password, Err: validation.ErrRequired})
+	}
+	if err := validgenRule9.Check(v.Confirm, v.Password); err != nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Confirm", Value: v.Confirm, Err: err})
+	}
+	if err := validgenRule10.Check(v.End, v.Start); err != nil {
+		*errs = append(*errs, &validation.Error{FieldName: "End", Value: v.End, Err: err})
+	}
+	*errs = append(*errs, validation.CheckField("Ref", v.Ref, "eqfield=Missing", v)...)
+	if err := validgenRule7.Check(v.Anon, nil); err != nil {
+		*errs = append(*errs, &validation.Error{FieldName: "Anon", Value: v.Anon, Err: err})
+	}
+	*errs = append(*errs, validation.StructErrors(&v.Anon)...)
+}
@@ -0,0 +1,71 @@
+// Command validgen generate reflection-free Validate methods from valid tags.
+//
+// Usage:
+//
+//	validgen [-type T1,T2] [-output file] [dir]
+//
+// Add it to a package by go:generate:
+//
+//	//go:generate validgen -type User,Order
+//
+// Every type gets "func (v *T) Validate() error", which returns
+// validation.Errors same as Validation.Errs(). Scalars, pointers, slices
+// and nested structs of the package are checked by direct field access,
+// other fields, such as map and interface, fall back to reflection.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: validgen [-type T1,T2] [-output file] [dir]\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated struct names, default all structs with valid tags")
+	output := flag.String("output", "", "output file name, default <package>_valid.go in dir")
+
+	log.SetFlags(0)
+	log.SetPrefix("validgen: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 1 {
+		usage()
+		os.Exit(2)
+	} else if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+
+	var names []string
+	if *typeNames != "" {
+		names = strings.Split(*typeNames, ",")
+	}
+
+	pkg, err := loadPackage(dir, *output)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	name := *output
+	if name == "" {
+		name = filepath.Join(dir, pkg.Name()+"_valid.go")
+	}
+
+	src, err := generate(pkg, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(name, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
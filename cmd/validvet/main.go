@@ -0,0 +1,20 @@
+// Command validvet report mistakes in valid tags, such as unknown rules,
+// bad params and rules on fields of wrong type.
+//
+// Usage:
+//
+//	validvet [-rules even,odd] ./...
+//
+// Or run by go vet:
+//
+//	go vet -vettool=$(which validvet) ./...
+package main
+
+import (
+	"github.com/DavadDi/validation/validvet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(validvet.Analyzer)
+}
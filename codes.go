@@ -0,0 +1,305 @@
+package validation
+
+import (
+	_ "embed" // code tables in data dir
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // IANA timezones without system zoneinfo
+)
+
+// Standard code checker names
+//
+//	country_code          ISO 3166-1 alpha-2, alpha-3 or numeric
+//	country_code=alpha2   only alpha-2, param can be alpha2, alpha3 or numeric
+//	currency              ISO 4217 alphabetic code
+//	language              BCP 47 well-formed language tag
+//	timezone              IANA timezone name
+const (
+	CountryCodeKey = "country_code"
+	CurrencyKey    = "currency"
+	LanguageKey    = "language"
+	TimezoneKey    = "timezone"
+
+	countryAlpha2  = "alpha2"
+	countryAlpha3  = "alpha3"
+	countryNumeric = "numeric"
+
+	versionPrefix = "# version:"
+)
+
+var (
+	//go:embed data/iso3166-1.txt
+	countryData string
+
+	//go:embed data/iso4217.txt
+	currencyData string
+
+	countryAlpha2Set, countryAlpha3Set, countryNumericSet = loadCountries()
+	currencySet                                           = loadCurrencies()
+
+	// CountryCodesVersion version of embedded ISO 3166-1 table
+	CountryCodesVersion = dataVersion(countryData)
+	// CurrencyCodesVersion version of embedded ISO 4217 table
+	CurrencyCodesVersion = dataVersion(currencyData)
+
+	// Loaded timezones, LoadLocation read tzdata every call
+	timezones sync.Map
+)
+
+// Return version from "# version:" header of code table
+func dataVersion(data string) string {
+	for _, line := range strings.Split(data, "\n") {
+		if strings.HasPrefix(line, versionPrefix) {
+			return strings.TrimSpace(line[len(versionPrefix):])
+		}
+	}
+
+	return ""
+}
+
+// Return fields of code table lines, skip comments and empty lines
+func dataRows(data string) [][]string {
+	var rows [][]string
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rows = append(rows, strings.Fields(line))
+	}
+
+	return rows
+}
+
+func loadCountries() (alpha2, alpha3, numeric map[string]bool) {
+	alpha2 = make(map[string]bool)
+	alpha3 = make(map[string]bool)
+	numeric = make(map[string]bool)
+
+	for _, row := range dataRows(countryData) {
+		alpha2[row[0]] = true
+		alpha3[row[1]] = true
+		numeric[row[2]] = true
+	}
+
+	return alpha2, alpha3, numeric
+}
+
+func loadCurrencies() map[string]bool {
+	set := make(map[string]bool)
+	for _, row := range dataRows(currencyData) {
+		set[row[0]] = true
+	}
+
+	return set
+}
+
+func countryCodeBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	var sets []map[string]bool
+
+	switch param {
+	case "":
+		sets = []map[string]bool{countryAlpha2Set, countryAlpha3Set, countryNumericSet}
+	case countryAlpha2:
+		sets = []map[string]bool{countryAlpha2Set}
+	case countryAlpha3:
+		sets = []map[string]bool{countryAlpha3Set}
+	case countryNumeric:
+		sets = []map[string]bool{countryNumericSet}
+	default:
+		return nil, fmt.Errorf("bad country code form [%s], should be alpha2, alpha3 or numeric", param)
+	}
+
+	return func(v interface{}) error {
+		var code string
+
+		// Numeric code can be any integer type, "004" == 4
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			code = fmt.Sprintf("%03d", rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			code = fmt.Sprintf("%03d", rv.Uint())
+		default:
+			str, ok := v.(string)
+			if !ok {
+				return NewErrWrongType("string or int", v)
+			}
+			code = str
+		}
+
+		for _, set := range sets {
+			if set[code] {
+				return nil
+			}
+		}
+
+		return ErrBadCountryCode
+	}, nil
+}
+
+func currencyChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	if !currencySet[str] {
+		return ErrBadCurrency
+	}
+
+	return nil
+}
+
+func timezoneChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	// "" and "Local" are accepted by LoadLocation, but not timezone names
+	if str == "" || str == "Local" {
+		return ErrBadTimezone
+	}
+
+	if _, ok := timezones.Load(str); ok {
+		return nil
+	}
+
+	if _, err := time.LoadLocation(str); err != nil {
+		return ErrBadTimezone
+	}
+	timezones.Store(str, true)
+
+	return nil
+}
+
+func languageChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	if !isLanguageTag(str) {
+		return ErrBadLanguageTag
+	}
+
+	return nil
+}
+
+// Irregular grandfathered tags, RFC 5646 section 2.2.8
+var grandfatheredTags = map[string]bool{
+	"en-gb-oed": true, "i-ami": true, "i-bnn": true, "i-default": true, "i-enochian": true,
+	"i-hak": true, "i-klingon": true, "i-lux": true, "i-mingo": true, "i-navajo": true,
+	"i-pwn": true, "i-tao": true, "i-tay": true, "i-tsu": true, "sgn-be-fr": true,
+	"sgn-be-nl": true, "sgn-ch-de": true,
+}
+
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= 'a' && c <= 'z') {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+func isAlnum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// Check well-formed tag by RFC 5646 section 2.1 syntax, subtags are not
+// looked up in the IANA registry
+func isLanguageTag(tag string) bool {
+	tag = strings.ToLower(tag)
+	if grandfatheredTags[tag] {
+		return true
+	}
+
+	subtags := strings.Split(tag, "-")
+	i := 0
+	next := func() string {
+		if i < len(subtags) {
+			return subtags[i]
+		}
+		return ""
+	}
+
+	// privateuse only, "x-whatever"
+	if next() != "x" {
+		// language, 2*3ALPHA [extlang] / 4ALPHA / 5*8ALPHA
+		lang := next()
+		if !isAlpha(lang) || len(lang) < 2 || len(lang) > 8 {
+			return false
+		}
+		i++
+
+		// extlang, up to three 3ALPHA after 2-3 letters language
+		if len(lang) <= 3 {
+			for n := 0; n < 3 && len(next()) == 3 && isAlpha(next()); n++ {
+				i++
+			}
+		}
+
+		// script, 4ALPHA
+		if s := next(); len(s) == 4 && isAlpha(s) {
+			i++
+		}
+
+		// region, 2ALPHA / 3DIGIT
+		if s := next(); len(s) == 2 && isAlpha(s) || len(s) == 3 && isNumeric(s) {
+			i++
+		}
+
+		// variants, 5*8alphanum / (DIGIT 3alphanum)
+		for s := next(); isAlnum(s) && (len(s) >= 5 && len(s) <= 8 || len(s) == 4 && isNumeric(s[:1])); s = next() {
+			i++
+		}
+
+		// extensions, singleton 1*("-" (2*8alphanum))
+		for s := next(); len(s) == 1 && s != "x" && isAlnum(s); s = next() {
+			i++
+			n := 0
+			for e := next(); len(e) >= 2 && len(e) <= 8 && isAlnum(e); e = next() {
+				i++
+				n++
+			}
+			if n == 0 {
+				return false
+			}
+		}
+
+		if i == len(subtags) {
+			return true
+		}
+
+		if next() != "x" {
+			return false
+		}
+	}
+
+	// privateuse, "x" 1*("-" (1*8alphanum))
+	i++
+	if i == len(subtags) {
+		return false
+	}
+	for ; i < len(subtags); i++ {
+		if !isAlnum(subtags[i]) || len(subtags[i]) > 8 {
+			return false
+		}
+	}
+
+	return true
+}
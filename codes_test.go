@@ -0,0 +1,90 @@
+package validation
+
+import "testing"
+
+func TestCodeCheckers(t *testing.T) {
+	tests := []struct {
+		Checker ValidaterFunc
+		Value   interface{}
+		Expect  bool
+	}{
+		{currencyChecker, "CNY", true},
+		{currencyChecker, "EUR", true},
+		{currencyChecker, "cny", false},
+		{currencyChecker, "ABC", false},
+		{timezoneChecker, "Asia/Shanghai", true},
+		{timezoneChecker, "UTC", true},
+		{timezoneChecker, "Asia/Shanghai", true},
+		{timezoneChecker, "Local", false},
+		{timezoneChecker, "Mars/Olympus", false},
+		{languageChecker, "en", true},
+		{languageChecker, "zh-Hans-CN", true},
+		{languageChecker, "zh-yue-HK", true},
+		{languageChecker, "de-CH-1901", true},
+		{languageChecker, "es-419", true},
+		{languageChecker, "en-US-u-ca-gregory-x-private", true},
+		{languageChecker, "x-whatever", true},
+		{languageChecker, "i-klingon", true},
+		{languageChecker, "e", false},
+		{languageChecker, "en-", false},
+		{languageChecker, "en-u", false},
+		{languageChecker, "en-x", false},
+		{languageChecker, "en_US", false},
+		{languageChecker, "toolonglang", false},
+	}
+
+	for i, test := range tests {
+		err := test.Checker(test.Value)
+		if (err == nil) != test.Expect {
+			t.Errorf("case %d [%v] should [%t], got err %v", i, test.Value, test.Expect, err)
+		}
+	}
+}
+
+type testCountry uint16
+
+func TestCountryCode(t *testing.T) {
+	obj := struct {
+		Any     []string `valid:"country_code"`
+		Alpha2  string   `valid:"country_code=alpha2"`
+		Alpha3  string   `valid:"country_code=alpha3"`
+		Numeric int      `valid:"country_code=numeric"`
+		Bad     string   `valid:"country_code=alpha4"`
+	}{
+		Any:     []string{"CN", "CHN", "156", "XX"},
+		Alpha2:  "CHN",
+		Alpha3:  "CHN",
+		Numeric: 4,
+	}
+
+	validor := NewValidation()
+	validor.Validate(obj)
+
+	// XX, Alpha2 and Bad form
+	if len(validor.Errs()) != 3 {
+		t.Errorf("should got 3 errors, but got %s", validor.ErrMsg())
+	}
+
+	numeric := NewValidation()
+	for _, code := range []interface{}{int8(4), int16(156), uint8(4), uint16(840), int64(4), testCountry(156)} {
+		numeric.Reset()
+		if !numeric.ValidateVar(code, "country_code=numeric") {
+			t.Errorf("numeric code %T %v should be valid, but got %s", code, code, numeric.ErrMsg())
+		}
+	}
+
+	for _, code := range []interface{}{int8(-4), uint32(999), 4.0} {
+		numeric.Reset()
+		if numeric.ValidateVar(code, "country_code=numeric") {
+			t.Errorf("numeric code %T %v should be invalid", code, code)
+		}
+	}
+
+	if CountryCodesVersion == "" || CurrencyCodesVersion == "" {
+		t.Errorf("embedded data should have version")
+	}
+
+	if len(countryAlpha2Set) != 249 || len(countryAlpha3Set) != 249 || len(countryNumericSet) != 249 {
+		t.Errorf("should load 249 countries, got %d", len(countryAlpha2Set))
+	}
+}
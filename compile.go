@@ -0,0 +1,226 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// compiler walk type graph for Compile, problems are collected, not
+// stopped at first one
+type compiler struct {
+	mv   *Validation
+	seen map[reflect.Type]bool
+	errs []*ErrRule
+}
+
+// Compile compile plans of struct type and all nested struct types, return
+// *ErrCompile listing every bad rule, such as unknown name, bad param, "@name"
+// pattern not registered, rule on field of wrong type or cross field rule with
+// missing field. obj can be a
+// value, a nil ptr such as (*User)(nil) or reflect.Type.
+//
+// Plans are cached, so Validate won't compile them again. Call it at init:
+//
+//	mv := validation.NewValidation()
+//	mv.MustCompile((*User)(nil))
+func (mv *Validation) Compile(obj interface{}) error {
+	t, ok := obj.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(obj)
+	}
+
+	if t == nil {
+		return &ErrOnlyStrcut{Type: reflect.TypeOf((*interface{})(nil)).Elem()}
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return &ErrOnlyStrcut{Type: t}
+	}
+
+	c := &compiler{mv: mv, seen: make(map[reflect.Type]bool)}
+	c.structType(t, t.Name())
+
+	if len(c.errs) > 0 {
+		return &ErrCompile{Type: t, Rules: c.errs}
+	}
+
+	return nil
+}
+
+// MustCompile like Compile but panic on error, services can fail at boot
+func (mv *Validation) MustCompile(obj interface{}) {
+	if err := mv.Compile(obj); err != nil {
+		panic(err)
+	}
+}
+
+func (c *compiler) addError(path, name string, err error) {
+	c.errs = append(c.errs, &ErrRule{Field: path, Rule: name, Err: err})
+}
+
+// Rules never run on struct fields
+var (
+	errEmbeddedRule = errors.New("rule of embedded struct is never checked, only required is")
+	errStructRule   = errors.New("rule of struct field is never checked, struct is validated by its own tags")
+)
+
+// Check plan of struct t, path is shown in errors, "User.Address"
+func (c *compiler) structType(t reflect.Type, path string) {
+	if c.seen[t] {
+		return
+	}
+	c.seen[t] = true
+
+	// Private fields are skipped by plan, tag on them is a mistake
+	for _, tf := range reflect.VisibleFields(t) {
+		tag, fpath, ok := promotedTag(t, tf)
+		if ok && !tf.Anonymous && len(tf.PkgPath) > 0 && len(parseTag(tag)) > 0 {
+			c.addError(joinPath(path, fpath), "", errors.New("valid tag of unexported field is never checked"))
+		}
+	}
+
+	for _, fp := range c.mv.planFor(t).fields {
+		fpath := joinPath(path, fp.path)
+
+		if fp.embedded {
+			c.ruleTypes(fpath, fp, t, nil, errEmbeddedRule)
+			continue
+		}
+
+		elem := valueType(fp.field.Type)
+		if !isSupportedType(elem) {
+			c.addError(fpath, "", fmt.Errorf("unsupported type %s, check always fails", elem))
+			continue
+		}
+
+		if elem.Kind() == reflect.Struct && !isValueStruct(elem) {
+			c.ruleTypes(fpath, fp, t, nil, errStructRule)
+			c.structType(elem, fpath)
+			continue
+		}
+
+		c.ruleTypes(fpath, fp, t, elem, nil)
+	}
+}
+
+// Return type checked by rules, elements of pointer, slice, array and map
+func valueType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// Chan, func and complex fields get UnspportType error from typeCheck
+func isSupportedType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return false
+	}
+
+	return true
+}
+
+// Check rules of field, elem is type passed to checkers. never is not nil
+// if rules are never run on the field.
+func (c *compiler) ruleTypes(path string, fp *fieldPlan, t, elem reflect.Type, never error) {
+	for _, r := range fp.rules {
+		if err := resolveRule(r); err != nil {
+			c.addError(path, r.name, err)
+			continue
+		}
+
+		if err := unknownPattern(r.name, r.param); err != nil {
+			c.addError(path, r.name, err)
+			continue
+		}
+
+		if never != nil {
+			c.addError(path, r.name, never)
+			continue
+		}
+
+		if err := c.probe(r, t, elem); err != nil {
+			c.addError(path, r.name, err)
+		}
+	}
+}
+
+// Run builtin checker on zero value, only wrong type error is returned.
+// Custom checkers are not run, they may have side effects.
+func (c *compiler) probe(r *rule, t, elem reflect.Type) error {
+	// Dynamic type is only known at runtime
+	dynamic := elem.Kind() == reflect.Interface
+
+	var err error
+	switch {
+	case r.cross != nil:
+		if r.param == "" {
+			return fmt.Errorf("[%s] need other field name", r.name)
+		}
+
+		sf, ok := t.FieldByName(r.param)
+		if !ok || len(sf.PkgPath) > 0 {
+			return fmt.Errorf("can't find field [%s] for [%s]", r.param, r.name)
+		}
+
+		other := sf.Type
+		if other.Kind() == reflect.Ptr {
+			other = other.Elem()
+		}
+
+		if dynamic || other.Kind() == reflect.Interface {
+			return nil
+		}
+		err = r.cross(reflect.Zero(elem).Interface(), reflect.Zero(other).Interface())
+	case dynamic:
+	default:
+		return probeType(r, elem)
+	}
+
+	return wrongType(err)
+}
+
+// ProbeRule return *ErrWrongExpectType if builtin rule never accept value of
+// type t, found by running checker on zero value same as Compile. Unknown,
+// custom and cross field rules return nil. Tools such as validvet use it.
+func ProbeRule(name, param string, t reflect.Type) error {
+	r := Generated.compileRule(tagRule{name: name, param: param})
+	if r.err != nil || r.cross != nil || t.Kind() == reflect.Interface {
+		return nil
+	}
+
+	return probeType(r, t)
+}
+
+// Run builtin checker of r on zero value of t
+func probeType(r *rule, t reflect.Type) error {
+	var err error
+	switch {
+	case r.fn != nil:
+		err = r.fn(reflect.Zero(t).Interface())
+	case validatorsMap[r.name] != nil:
+		err = validatorsMap[r.name](reflect.Zero(t).Interface())
+	}
+
+	return wrongType(err)
+}
+
+// Return err if it is *ErrWrongExpectType, nil for others
+func wrongType(err error) error {
+	var wrong *ErrWrongExpectType
+	if errors.As(err, &wrong) {
+		return err
+	}
+
+	return nil
+}
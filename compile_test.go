@@ -0,0 +1,169 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type CompileBase struct {
+	ID string `valid:"uuid"`
+}
+
+type CompileAddress struct {
+	City string `valid:"required;max_len=x"`
+	Zip  int    `valid:"regex=^[0-9]+$"`
+	Next *CompileAddress
+}
+
+type CompileUser struct {
+	CompileBase `valid:"required;email"`
+
+	Name     string            `valid:"requried;min_len=2"`
+	Age      int               `valid:"email"`
+	Port     int               `valid:"port"`
+	Level    int               `valid:"oneof=1,2"`
+	Tags     []string          `valid:"regex=^[a-z]+$"`
+	Hosts    map[string]*int   `valid:"ip"`
+	Born     *time.Time        `valid:"past"`
+	Created  string            `valid:"past"`
+	Home     []CompileAddress  `valid:"required;email"`
+	Extra    interface{}       `valid:"email"`
+	Password string            `valid:"required"`
+	Confirm  string            `valid:"eqfield=Pasword"`
+	Start    time.Time         ``
+	End      time.Time         `valid:"gtfield=Start"`
+	Limit    int               `valid:"ltfield=Name"`
+	Labels   map[string]string `valid:"-"`
+	Code     string            `valid:"not_regex=@compile_missing"`
+	token    string            `valid:"required"`
+}
+
+type CompileGood struct {
+	Name  string    `valid:"required;min_len=2;email=strict"`
+	Level int       `valid:"oneof=1,2;compile_even"`
+	Start time.Time `valid:"past"`
+	End   time.Time `valid:"gtfield=Start"`
+	Items []CompileGoodItem
+	Item  *CompileGoodItem `valid:"required"`
+}
+
+type CompileGoodItem struct {
+	SKU  string `valid:"required;regex=^[A-Z]+$"`
+	Code string `valid:"regex=@compile_code"`
+}
+
+func TestCompile(t *testing.T) {
+	AddValidater("compile_even", func(v interface{}) error { return nil })
+	if err := RegisterPattern("compile_code", "^[0-9]+$"); err != nil {
+		t.Fatal(err)
+	}
+
+	mv := NewValidation()
+	if err := mv.Compile((*CompileGood)(nil)); err != nil {
+		t.Fatalf("Compile(CompileGood) = %v", err)
+	}
+
+	// Plan is cached
+	if _, ok := mv.plans[reflect.TypeOf(CompileGoodItem{})]; !ok {
+		t.Errorf("Compile didn't cache plan of nested struct")
+	}
+
+	err := mv.Compile(CompileUser{})
+
+	var ce *ErrCompile
+	if !errors.As(err, &ce) {
+		t.Fatalf("Compile(CompileUser) = %v, want *ErrCompile", err)
+	}
+
+	want := []string{
+		"[CompileUser.token] valid tag of unexported field is never checked",
+		"[CompileUser.CompileBase] rule [email]: rule of embedded struct is never checked, only required is",
+		"[CompileUser.Name] rule [requried]: can't find checker for [requried]",
+		"[CompileUser.Age] rule [email]: expect type string, but got int",
+		"[CompileUser.Hosts] rule [ip]: expect type string, but got int",
+		"[CompileUser.Created] rule [past]: expect type time.Time, but got string",
+		"[CompileUser.Home] rule [email]: rule of struct field is never checked, struct is validated by its own tags",
+		"[CompileUser.Home.City] rule [max_len]: max_len need non-negative int, but got [x]",
+		"[CompileUser.Home.Zip] rule [regex]: expect type string, but got int",
+		"[CompileUser.Confirm] rule [eqfield]: can't find field [Pasword] for [eqfield]",
+		"[CompileUser.Limit] rule [ltfield]: expect type int, but got string",
+		"[CompileUser.Code] rule [not_regex]: bad pattern [@compile_missing]: can't find pattern [compile_missing]",
+	}
+
+	var got []string
+	for _, r := range ce.Rules {
+		got = append(got, r.Error())
+	}
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Compile(CompileUser) errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if !strings.HasPrefix(err.Error(), "compile validation.CompileUser failed with 12 errors:\n\t[CompileUser.token]") {
+		t.Errorf("ErrCompile.Error() = %q", err.Error())
+	}
+}
+
+func TestCompileNotStruct(t *testing.T) {
+	mv := NewValidation()
+
+	for _, obj := range []interface{}{nil, 1, "str", []CompileGood{}} {
+		var ose *ErrOnlyStrcut
+		if err := mv.Compile(obj); !errors.As(err, &ose) {
+			t.Errorf("Compile(%#v) = %v, want *ErrOnlyStrcut", obj, err)
+		}
+	}
+
+	if err := mv.Compile(reflect.TypeOf(CompileGoodItem{})); err != nil {
+		t.Errorf("Compile(reflect.Type) = %v", err)
+	}
+}
+
+func TestMustCompile(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("MustCompile(CompileAddress) didn't panic")
+		}
+	}()
+
+	NewValidation().MustCompile(&CompileAddress{})
+}
+
+func TestProbeRule(t *testing.T) {
+	type level uint8
+
+	tests := []struct {
+		name, param string
+		typ         reflect.Type
+		wrong       bool
+	}{
+		{"email", "", reflect.TypeOf(""), false},
+		{"email", "", reflect.TypeOf(0), true},
+		{"port", "", reflect.TypeOf(level(0)), false},
+		{"port", "", reflect.TypeOf(1.5), true},
+		{"past", "", reflect.TypeOf(""), true},
+		{"min_duration", "1s", reflect.TypeOf(time.Duration(0)), false},
+		{"eqfield", "Other", reflect.TypeOf(0), false},
+		{"not_found", "", reflect.TypeOf(0), false},
+		{"email", "", reflect.TypeOf((*interface{})(nil)).Elem(), false},
+	}
+
+	for _, test := range tests {
+		err := ProbeRule(test.name, test.param, test.typ)
+
+		var wrong *ErrWrongExpectType
+		if errors.As(err, &wrong) != test.wrong {
+			t.Errorf("ProbeRule(%s, %s) = %v, want wrong type %v", test.name, test.typ, err, test.wrong)
+		}
+	}
+
+	// Every rule can be probed, zero values don't panic
+	for _, name := range RuleNames() {
+		for _, typ := range []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(0), reflect.TypeOf(time.Time{})} {
+			ProbeRule(name, "", typ)
+		}
+	}
+}
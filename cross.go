@@ -0,0 +1,165 @@
+package validation
+
+import (
+	"cmp"
+	"reflect"
+	"time"
+)
+
+// Cross field checker names, param is other field of same struct. Without
+// param, other value is the one passed to ValidateVarWithValue.
+//
+//	eqfield=Password     equal to field Password
+//	nefield=OldPassword  not equal to field OldPassword
+//	gtfield=Start        greater than field Start
+//	gtefield=Start       greater than or equal to field Start
+//	ltfield=End          less than field End
+//	ltefield=End         less than or equal to field End
+//
+// Numbers, strings, time.Time and time.Duration can be ordered, eqfield and
+// nefield accept any comparable values.
+const (
+	EqFieldKey  = "eqfield"
+	NeFieldKey  = "nefield"
+	GtFieldKey  = "gtfield"
+	GteFieldKey = "gtefield"
+	LtFieldKey  = "ltfield"
+	LteFieldKey = "ltefield"
+)
+
+// crossFunc check value against other value
+type crossFunc func(v, other interface{}) error
+
+// Cross field checkers, looked up before ruleBuilders
+var crossCheckers = map[string]crossFunc{
+	EqFieldKey:  equalChecker(EqFieldKey, true),
+	NeFieldKey:  equalChecker(NeFieldKey, false),
+	GtFieldKey:  orderChecker(GtFieldKey, func(c int) bool { return c > 0 }),
+	GteFieldKey: orderChecker(GteFieldKey, func(c int) bool { return c >= 0 }),
+	LtFieldKey:  orderChecker(LtFieldKey, func(c int) bool { return c < 0 }),
+	LteFieldKey: orderChecker(LteFieldKey, func(c int) bool { return c <= 0 }),
+}
+
+// Return other value for cross field checker, o is struct of field, object of
+// ValidateMap or other value of ValidateVarWithValue
+func otherValue(o reflect.Value, field string) (interface{}, bool) {
+	switch {
+	case field == "":
+	case o.Kind() == reflect.Struct:
+		sf, ok := o.Type().FieldByName(field)
+		if !ok {
+			return nil, false
+		}
+
+		f, err := o.FieldByIndexErr(sf.Index)
+		if err != nil || !f.CanInterface() {
+			return nil, false
+		}
+		o = f
+	case o.Kind() == reflect.Map && o.Type().Key().Kind() == reflect.String:
+		// Other key of object in ValidateMap
+		o = o.MapIndex(reflect.ValueOf(field).Convert(o.Type().Key()))
+	default:
+		return nil, false
+	}
+
+	if o.Kind() == reflect.Interface && !o.IsNil() {
+		o = o.Elem()
+	}
+
+	if !o.IsValid() {
+		return nil, false
+	}
+
+	// Compare pointed value, nil pointer is compared as nil
+	if o.Kind() == reflect.Ptr {
+		if o.IsNil() {
+			return nil, true
+		}
+		o = o.Elem()
+	}
+
+	return o.Interface(), true
+}
+
+func equalChecker(name string, equal bool) crossFunc {
+	return func(v, other interface{}) error {
+		if reflect.DeepEqual(v, other) != equal {
+			return &ErrFieldMismatch{Rule: name}
+		}
+
+		return nil
+	}
+}
+
+func orderChecker(name string, ok func(c int) bool) crossFunc {
+	return func(v, other interface{}) error {
+		c, err := compareValues(v, other)
+		if err != nil {
+			return err
+		}
+
+		if !ok(c) {
+			return &ErrFieldMismatch{Rule: name}
+		}
+
+		return nil
+	}
+}
+
+// Compare ordered values, return -1, 0 or 1, mixed ints and floats are
+// compared as float64
+func compareValues(a, b interface{}) (int, error) {
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		if !ok {
+			return 0, NewErrWrongType("time.Time", b)
+		}
+		return ta.Compare(tb), nil
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	ka, kb := kindClass(va), kindClass(vb)
+
+	switch {
+	case ka == "" || kb == "":
+		return 0, NewErrWrongType("number, string, time.Time or time.Duration", a)
+	case ka == "string" && kb == "string":
+		return cmp.Compare(va.String(), vb.String()), nil
+	case ka == "string" || kb == "string":
+		return 0, NewErrWrongType(ka, b)
+	case ka == "int" && kb == "int":
+		return cmp.Compare(va.Int(), vb.Int()), nil
+	case ka == "uint" && kb == "uint":
+		return cmp.Compare(va.Uint(), vb.Uint()), nil
+	}
+
+	return cmp.Compare(floatValue(va), floatValue(vb)), nil
+}
+
+// Return "int", "uint", "float" or "string" for ordered kinds
+func kindClass(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.String:
+		return "string"
+	}
+
+	return ""
+}
+
+func floatValue(v reflect.Value) float64 {
+	switch kindClass(v) {
+	case "int":
+		return float64(v.Int())
+	case "uint":
+		return float64(v.Uint())
+	}
+
+	return v.Float()
+}
@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type Signup struct {
+	Password string `valid:"required"`
+	Confirm  string `valid:"eqfield=Password"`
+	Old      string `valid:"nefield=Password"`
+}
+
+type Reservation struct {
+	Start  time.Time
+	End    time.Time `valid:"gtfield=Start"`
+	Min    int
+	Max    int   `valid:"gtefield=Min"`
+	Tries  []int `valid:"ltefield=Max"`
+	Limit  *int
+	Amount int `valid:"ltfield=Limit"`
+	Nobody int `valid:"eqfield=Missing"`
+}
+
+func TestCrossField(t *testing.T) {
+	validor := NewValidation()
+
+	if !validor.Validate(&Signup{Password: "a", Confirm: "a", Old: "b"}) {
+		t.Errorf("Validate should succeed, but got %s", validor.ErrMsg())
+	}
+
+	validor.Reset()
+	if validor.Validate(&Signup{Password: "a", Confirm: "b", Old: "a"}) {
+		t.Fatalf("Validate should failed for mismatch fields")
+	}
+
+	if len(validor.Errs()) != 2 || validor.Errs()[0].FieldName != "Confirm" || validor.Errs()[1].FieldName != "Old" {
+		t.Errorf("should got errors on Confirm and Old, but got %s", validor.ErrMsg())
+	}
+
+	var mismatch *ErrFieldMismatch
+	if !errors.As(validor.Errs()[0].Err, &mismatch) || mismatch.Rule != EqFieldKey || mismatch.Field != "Password" {
+		t.Errorf("should got ErrFieldMismatch, but got %v", validor.Errs()[0].Err)
+	}
+
+	now := time.Now()
+	limit := 10
+	validor.Reset()
+	validor.Validate(&Reservation{Start: now, End: now, Min: 2, Max: 2, Tries: []int{1, 3}, Limit: &limit, Amount: 10})
+
+	want := []string{"End", "Tries", "Amount", "Nobody"}
+	if len(validor.Errs()) != len(want) {
+		t.Fatalf("should got errors on %v, but got %s", want, validor.ErrMsg())
+	}
+
+	for i, name := range want {
+		if validor.Errs()[i].FieldName != name {
+			t.Errorf("error %d should be on %s, but got %s", i, name, validor.Errs()[i].FieldName)
+		}
+	}
+}
+
+func TestAddValidaterCrossName(t *testing.T) {
+	if err := AddValidater(EqFieldKey, func(v interface{}) error { return nil }); err != ErrValidaterExists {
+		t.Errorf("AddValidater should failed for cross field checker, but got %v", err)
+	}
+}
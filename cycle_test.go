@@ -0,0 +1,76 @@
+package validation
+
+import "testing"
+
+type TreeNode struct {
+	Name     string      `valid:"required"`
+	Parent   *TreeNode   `valid:"required"`
+	Children []*TreeNode `valid:"required"`
+}
+
+type ListNode struct {
+	Value int       `valid:"required"`
+	Next  *ListNode `valid:"required"`
+}
+
+func TestCycle(t *testing.T) {
+	root := &TreeNode{Name: "root"}
+	root.Parent = root
+	child := &TreeNode{Name: "", Parent: root}
+	root.Children = []*TreeNode{child, child}
+	child.Children = []*TreeNode{root}
+
+	validor := NewValidation()
+	if validor.Validate(root) {
+		t.Fatalf("Validate should failed for child without name")
+	}
+
+	// child checked once, though referenced twice
+	if len(validor.Errs()) != 1 || validor.Errs()[0].FieldName != "Name" {
+		t.Errorf("should got 1 error on Name, but got %s", validor.ErrMsg())
+	}
+
+	a := &ListNode{Value: 1}
+	b := &ListNode{Value: 2, Next: a}
+	a.Next = b
+
+	validor.Reset()
+	if !validor.Validate(a) {
+		t.Errorf("cyclic list should succeed, but got %s", validor.ErrMsg())
+	}
+
+	// visited is reset for every Validate
+	validor.Reset()
+	b.Value = 0
+	if validor.Validate(a) {
+		t.Errorf("Validate should failed for zero value")
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	head := &ListNode{Value: 1}
+	node := head
+	for i := 0; i < 10; i++ {
+		node.Next = &ListNode{Value: 1}
+		node = node.Next
+	}
+	node.Next = head
+
+	validor := NewValidation()
+	validor.SetMaxDepth(5)
+	validor.Validate(head)
+
+	if len(validor.Errs()) != 1 {
+		t.Fatalf("should got 1 error, but got %s", validor.ErrMsg())
+	}
+
+	if err, ok := validor.Errs()[0].Err.(*ErrMaxDepth); !ok || err.Depth != 5 {
+		t.Errorf("should got ErrMaxDepth, but got %s", validor.ErrMsg())
+	}
+
+	validor.Reset()
+	validor.SetMaxDepth(0)
+	if !validor.Validate(head) {
+		t.Errorf("default depth should be enough, but got %s", validor.ErrMsg())
+	}
+}
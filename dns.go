@@ -0,0 +1,221 @@
+package validation
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DNS checker names, opt-in because they need network
+//
+//	email_mx           email domain has MX records, or A/AAAA as implicit MX
+//	resolvable_host    hostname has A/AAAA records
+const (
+	EmailMXKey        = "email_mx"
+	ResolvableHostKey = "resolvable_host"
+
+	defaultLookupTimeout = 3 * time.Second
+	defaultLookupTTL     = 5 * time.Minute
+
+	// Max lookup results kept by one Validation
+	maxLookupCacheSize = 1024
+)
+
+// Resolver lookup dns records for email_mx and resolvable_host,
+// *net.Resolver implement it
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// Cached lookup result
+type lookupResult struct {
+	key     string
+	err     error
+	expires time.Time
+}
+
+// dnsCache bounded lru cache of lookup results by record type and name,
+// expired results are removed when found
+type dnsCache struct {
+	max   int
+	ll    *list.List
+	items map[string]*list.Element
+	sync.Mutex
+}
+
+func newDNSCache(max int) *dnsCache {
+	return &dnsCache{
+		max:   max,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Return cached result not expired at now
+func (dc *dnsCache) get(key string, now time.Time) (lookupResult, bool) {
+	dc.Lock()
+	defer dc.Unlock()
+
+	e, ok := dc.items[key]
+	if !ok {
+		return lookupResult{}, false
+	}
+
+	res := e.Value.(*lookupResult)
+	if !now.Before(res.expires) {
+		dc.ll.Remove(e)
+		delete(dc.items, key)
+		return lookupResult{}, false
+	}
+
+	dc.ll.MoveToFront(e)
+	return *res, true
+}
+
+// Add result, the oldest one is evicted if full
+func (dc *dnsCache) put(key string, err error, expires time.Time) {
+	dc.Lock()
+	defer dc.Unlock()
+
+	if e, ok := dc.items[key]; ok {
+		dc.ll.Remove(e)
+	}
+
+	dc.items[key] = dc.ll.PushFront(&lookupResult{key: key, err: err, expires: expires})
+	if dc.ll.Len() > dc.max {
+		e := dc.ll.Back()
+		dc.ll.Remove(e)
+		delete(dc.items, e.Value.(*lookupResult).key)
+	}
+}
+
+// SetResolver replace net.DefaultResolver for dns checkers, nil reset to default.
+// Call it before Validate, tests can use a local fake resolver.
+func (mv *Validation) SetResolver(r Resolver) {
+	mv.resolver = r
+}
+
+// SetLookupTimeout set deadline for every dns lookup, default 3s
+func (mv *Validation) SetLookupTimeout(d time.Duration) {
+	mv.lookupTimeout = d
+}
+
+// Lookup by fn with deadline, result cached for defaultLookupTTL
+func (mv *Validation) lookup(key string, fn func(ctx context.Context, r Resolver) error) error {
+	mv.dnsOnce.Do(func() {
+		mv.dns = newDNSCache(maxLookupCacheSize)
+	})
+
+	now := mv.now()
+	if res, ok := mv.dns.get(key, now); ok {
+		return res.err
+	}
+
+	var r Resolver = net.DefaultResolver
+	if mv.resolver != nil {
+		r = mv.resolver
+	}
+
+	timeout := mv.lookupTimeout
+	if timeout <= 0 {
+		timeout = defaultLookupTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	err := fn(ctx, r)
+	cancel()
+
+	// Don't cache timeout and temporary failure, next check may succeed
+	var dnsErr *net.DNSError
+	if ctx.Err() == context.DeadlineExceeded || errors.As(err, &dnsErr) && dnsErr.IsTemporary {
+		return err
+	}
+
+	mv.dns.put(key, err, now.Add(defaultLookupTTL))
+
+	return err
+}
+
+// Return nil if host has A/AAAA records
+func (mv *Validation) resolveHost(host string) error {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	return mv.lookup("host:"+host, func(ctx context.Context, r Resolver) error {
+		addrs, err := r.LookupHost(ctx, host)
+		if err != nil {
+			return &ErrUnresolvable{Host: host, Err: err}
+		}
+
+		if len(addrs) == 0 {
+			return &ErrUnresolvable{Host: host}
+		}
+
+		return nil
+	})
+}
+
+// Return nil if domain has MX records, or A/AAAA records as implicit MX
+func (mv *Validation) resolveMX(domain string) error {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	return mv.lookup("mx:"+domain, func(ctx context.Context, r Resolver) error {
+		mxs, err := r.LookupMX(ctx, domain)
+		if err == nil && len(mxs) > 0 {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return &ErrUnresolvable{Host: domain, Err: ctx.Err()}
+		}
+
+		addrs, err := r.LookupHost(ctx, domain)
+		if err != nil {
+			return &ErrUnresolvable{Host: domain, Err: err}
+		}
+
+		if len(addrs) == 0 {
+			return &ErrUnresolvable{Host: domain}
+		}
+
+		return nil
+	})
+}
+
+func emailMXBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	if err := noParam(EmailMXKey, param); err != nil {
+		return nil, err
+	}
+
+	return func(v interface{}) error {
+		domain, err := emailDomain(v)
+		if err != nil {
+			return err
+		}
+
+		return mv.resolveMX(domain)
+	}, nil
+}
+
+func resolvableHostBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	if err := noParam(ResolvableHostKey, param); err != nil {
+		return nil, err
+	}
+
+	return func(v interface{}) error {
+		host, err := stringValue(v)
+		if err != nil {
+			return err
+		}
+
+		if !isHostname(strings.TrimSuffix(host, ".")) {
+			return ErrBadHostnameFormat
+		}
+
+		return mv.resolveHost(host)
+	}, nil
+}
@@ -0,0 +1,161 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeResolver local records for dns checkers
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+	calls int
+	delay time.Duration
+	fail  error // returned by LookupHost if set
+}
+
+func (r *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	r.calls++
+	if mxs, ok := r.mx[name]; ok {
+		return mxs, nil
+	}
+
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.calls++
+	if r.delay > 0 {
+		select {
+		case <-time.After(r.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if r.fail != nil {
+		return nil, r.fail
+	}
+
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestDNSCheckers(t *testing.T) {
+	r := &fakeResolver{
+		mx:    map[string][]*net.MX{"do1618.com": {{Host: "mx.do1618.com.", Pref: 10}}},
+		hosts: map[string][]string{"163.com": {"1.2.3.4"}, "www.do1618.com": {"1.2.3.5"}},
+	}
+
+	type Signup struct {
+		Email string `valid:"email;email_mx"`
+		Site  string `valid:"resolvable_host"`
+	}
+
+	tests := []struct {
+		Signup Signup
+		Expect int
+	}{
+		{Signup{"dave@do1618.com", "www.do1618.com"}, 0},
+		{Signup{"dave@163.com", "www.do1618.com."}, 0},
+		{Signup{"dave@nowhere.com", "nowhere.com"}, 2},
+	}
+
+	validor := NewValidation()
+	validor.SetResolver(r)
+
+	for i, test := range tests {
+		validor.Reset()
+		validor.Validate(test.Signup)
+
+		if len(validor.Errs()) != test.Expect {
+			t.Errorf("case %d should got [%d] errors, but got %s", i, test.Expect, validor.ErrMsg())
+		}
+	}
+
+	// Results are cached
+	calls := r.calls
+	validor.Reset()
+	validor.Validate(tests[0].Signup)
+	if r.calls != calls {
+		t.Errorf("lookup should be cached, but got %d new calls", r.calls-calls)
+	}
+
+	var ue *ErrUnresolvable
+	if !errors.As(validor.resolveHost("nowhere.com"), &ue) || ue.Host != "nowhere.com" {
+		t.Errorf("should got ErrUnresolvable for nowhere.com")
+	}
+}
+
+func TestDNSTimeout(t *testing.T) {
+	r := &fakeResolver{hosts: map[string][]string{"slow.com": {"1.2.3.4"}}, delay: time.Second}
+
+	validor := NewValidation()
+	validor.SetResolver(r)
+	validor.SetLookupTimeout(10 * time.Millisecond)
+
+	err := validor.resolveHost("slow.com")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("should got deadline exceeded, but got %v", err)
+	}
+
+	r.delay = 0
+	if err := validor.resolveHost("slow.com"); err != nil {
+		t.Errorf("timeout should not be cached, but got %v", err)
+	}
+}
+
+func TestDNSTemporaryFailure(t *testing.T) {
+	r := &fakeResolver{
+		hosts: map[string][]string{"flaky.com": {"1.2.3.4"}},
+		fail:  &net.DNSError{Err: "server misbehaving", Name: "flaky.com", IsTemporary: true},
+	}
+
+	validor := NewValidation()
+	validor.SetResolver(r)
+
+	var dnsErr *net.DNSError
+	if err := validor.resolveHost("flaky.com"); !errors.As(err, &dnsErr) || !dnsErr.IsTemporary {
+		t.Fatalf("should got temporary dns error, but got %v", err)
+	}
+
+	r.fail = nil
+	if err := validor.resolveHost("flaky.com"); err != nil {
+		t.Errorf("temporary failure should not be cached, but got %v", err)
+	}
+}
+
+func TestDNSCache(t *testing.T) {
+	dc := newDNSCache(2)
+	now := time.Now()
+
+	for _, key := range []string{"host:a", "host:b", "host:c"} {
+		dc.put(key, nil, now.Add(time.Minute))
+	}
+
+	if dc.ll.Len() != 2 || len(dc.items) != 2 {
+		t.Errorf("cache should keep 2 results, but got %d", dc.ll.Len())
+	}
+
+	if _, ok := dc.get("host:a", now); ok {
+		t.Errorf("oldest result should be evicted")
+	}
+
+	if _, ok := dc.get("host:c", now); !ok {
+		t.Errorf("result should be cached")
+	}
+
+	if _, ok := dc.get("host:c", now.Add(time.Minute)); ok {
+		t.Errorf("expired result should not be returned")
+	}
+
+	if _, ok := dc.items["host:c"]; ok || dc.ll.Len() != 1 {
+		t.Errorf("expired result should be removed, but got %d results", dc.ll.Len())
+	}
+}
@@ -0,0 +1,264 @@
+package validation
+
+import (
+	_ "embed" // disposable domains in data dir
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Email checker names and options
+//
+//	email                       match Email pattern, same as before
+//	email=strict                parse by net/mail, same as email=limits,dot
+//	email=limits,no_idn         options separated by ","
+//	email_domain=company.com    domain in list, "*.company.com" for subdomains
+//	email_not_domain=qq.com     domain not in list, subdomains are matched too
+//
+// Options:
+//
+//	strict          limits and dot
+//	limits          RFC 5321 length limits, 64 for local part, 254 for address
+//	dot             domain should has a dot, "dave@localhost" is not allowed
+//	display_name    allow "Dave <dave@do1618.com>"
+//	no_idn          domain should be ascii
+//	no_disposable   domain not in embedded disposable domains
+const (
+	EmailKey          = "email"
+	EmailDomainKey    = "email_domain"
+	EmailNotDomainKey = "email_not_domain"
+
+	emailStrict       = "strict"
+	emailLimits       = "limits"
+	emailDot          = "dot"
+	emailDisplayName  = "display_name"
+	emailNoIDN        = "no_idn"
+	emailNoDisposable = "no_disposable"
+
+	maxEmailLocalLen = 64
+	maxEmailLen      = 254
+)
+
+var (
+	//go:embed data/disposable_domains.txt
+	disposableData string
+
+	// DisposableDomainsVersion version of embedded disposable domains
+	DisposableDomainsVersion = dataVersion(disposableData)
+
+	disposableDomains = loadDisposableDomains()
+)
+
+func loadDisposableDomains() map[string]bool {
+	set := make(map[string]bool)
+	for _, row := range dataRows(disposableData) {
+		set[row[0]] = true
+	}
+
+	return set
+}
+
+// emailOptions options for email param
+type emailOptions struct {
+	limits       bool
+	dot          bool
+	displayName  bool
+	noIDN        bool
+	noDisposable bool
+}
+
+func parseEmailOptions(param string) (*emailOptions, error) {
+	opts := &emailOptions{}
+	for _, opt := range strings.Split(param, ",") {
+		switch strings.TrimSpace(opt) {
+		case emailStrict:
+			opts.limits = true
+			opts.dot = true
+		case emailLimits:
+			opts.limits = true
+		case emailDot:
+			opts.dot = true
+		case emailDisplayName:
+			opts.displayName = true
+		case emailNoIDN:
+			opts.noIDN = true
+		case emailNoDisposable:
+			opts.noDisposable = true
+		default:
+			return nil, fmt.Errorf("unknown email option [%s]", opt)
+		}
+	}
+
+	return opts, nil
+}
+
+// Return address and domain, with display name if allowed
+func splitEmail(str string, displayName bool) (string, string, error) {
+	addr, err := mail.ParseAddress(str)
+	if err != nil {
+		return "", "", &ErrBadEmail{Reason: err.Error()}
+	}
+
+	if !displayName && addr.Address != str {
+		return "", "", &ErrBadEmail{Reason: "display name is not allowed"}
+	}
+
+	i := strings.LastIndexByte(addr.Address, '@')
+	return addr.Address, addr.Address[i+1:], nil
+}
+
+// Check domain labels, letters of any language are allowed for IDN
+func checkEmailDomain(domain string, noIDN bool) error {
+	if len(domain) > maxHostnameLen {
+		return &ErrBadEmail{Reason: "domain too long"}
+	}
+
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > maxLabelLen || label[0] == '-' || label[len(label)-1] == '-' {
+			return &ErrBadEmail{Reason: fmt.Sprintf("bad domain label [%s]", label)}
+		}
+
+		for _, r := range label {
+			if r == '-' || r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				continue
+			}
+
+			if r >= utf8.RuneSelf && !noIDN && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)) {
+				continue
+			}
+
+			return &ErrBadEmail{Reason: fmt.Sprintf("bad char %q in domain", r)}
+		}
+	}
+
+	return nil
+}
+
+// Return true if domain or its parent is in set
+func domainInSet(domain string, set map[string]bool) bool {
+	for {
+		if set[domain] {
+			return true
+		}
+
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			return false
+		}
+		domain = domain[i+1:]
+	}
+}
+
+func emailBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	if param == "" {
+		return emailChecker, nil
+	}
+
+	opts, err := parseEmailOptions(param)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(v interface{}) error {
+		str, err := stringValue(v)
+		if err != nil {
+			return err
+		}
+
+		addr, domain, err := splitEmail(str, opts.displayName)
+		if err != nil {
+			return err
+		}
+
+		if opts.limits {
+			if len(addr)-len(domain)-1 > maxEmailLocalLen {
+				return &ErrBadEmail{Reason: fmt.Sprintf("local part longer than %d", maxEmailLocalLen)}
+			}
+
+			if len(addr) > maxEmailLen {
+				return &ErrBadEmail{Reason: fmt.Sprintf("address longer than %d", maxEmailLen)}
+			}
+		}
+
+		if opts.dot && !strings.Contains(domain, ".") {
+			return &ErrBadEmail{Reason: "domain should contain a dot"}
+		}
+
+		if err := checkEmailDomain(domain, opts.noIDN); err != nil {
+			return err
+		}
+
+		if opts.noDisposable && domainInSet(strings.ToLower(domain), disposableDomains) {
+			return &ErrBadEmail{Reason: fmt.Sprintf("disposable domain [%s]", domain)}
+		}
+
+		return nil
+	}, nil
+}
+
+// Return domain of email, email is checked by other rules
+func emailDomain(v interface{}) (string, error) {
+	str, err := stringValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	i := strings.LastIndexByte(str, '@')
+	if i < 0 {
+		return "", ErrBadEmailFormat
+	}
+
+	return strings.ToLower(strings.TrimSuffix(str[i+1:], ">")), nil
+}
+
+func emailDomainBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	if param == "" {
+		return nil, fmt.Errorf("email_domain need domain list")
+	}
+
+	domains := strings.Split(strings.ToLower(param), ",")
+	for i := range domains {
+		domains[i] = strings.TrimSpace(domains[i])
+	}
+
+	return func(v interface{}) error {
+		domain, err := emailDomain(v)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range domains {
+			if domain == d || strings.HasPrefix(d, "*.") && strings.HasSuffix(domain, d[1:]) {
+				return nil
+			}
+		}
+
+		return &ErrBadEmail{Reason: fmt.Sprintf("domain [%s] is not allowed", domain)}
+	}, nil
+}
+
+func emailNotDomainBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	if param == "" {
+		return nil, fmt.Errorf("email_not_domain need domain list")
+	}
+
+	set := make(map[string]bool)
+	for _, d := range strings.Split(strings.ToLower(param), ",") {
+		set[strings.TrimSpace(d)] = true
+	}
+
+	return func(v interface{}) error {
+		domain, err := emailDomain(v)
+		if err != nil {
+			return err
+		}
+
+		if domainInSet(domain, set) {
+			return &ErrBadEmail{Reason: fmt.Sprintf("domain [%s] is denied", domain)}
+		}
+
+		return nil
+	}, nil
+}
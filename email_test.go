@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEmailOptions(t *testing.T) {
+	long := strings.Repeat("a", 65) + "@do1618.com"
+
+	tests := []struct {
+		Param  string
+		Email  string
+		Expect bool
+	}{
+		{"strict", "dwh0403@163.com", true},
+		{"strict", "dave@localhost", false},
+		{"limits", "dave@localhost", true},
+		{"limits", long, false},
+		{"dot", long, true},
+		{"strict", "Dave <dave@do1618.com>", false},
+		{"strict,display_name", "Dave <dave@do1618.com>", true},
+		{"strict", "dave@bücher.de", true},
+		{"strict,no_idn", "dave@bücher.de", false},
+		{"strict", "dave@-bad.com", false},
+		{"strict", "dave@bad_host.com", false},
+		{"strict,no_disposable", "dave@mailinator.com", false},
+		{"strict,no_disposable", "dave@x.yopmail.com", false},
+		{"strict,no_disposable", "dave@do1618.com", true},
+	}
+
+	for i, test := range tests {
+		check, err := emailBuilder(nil, test.Param)
+		if err != nil {
+			t.Fatalf("case %d build failed %s", i, err)
+		}
+
+		err = check(test.Email)
+		if (err == nil) != test.Expect {
+			t.Errorf("case %d [%s] with [%s] should [%t], got err %v", i, test.Email, test.Param, test.Expect, err)
+		}
+
+		if err != nil && !errors.Is(err, ErrBadEmailFormat) {
+			t.Errorf("case %d error should be ErrBadEmailFormat, got %v", i, err)
+		}
+	}
+
+	if _, err := emailBuilder(nil, "strict,unknown"); err == nil {
+		t.Errorf("unknown option should failed")
+	}
+}
+
+func TestEmailDomain(t *testing.T) {
+	type Account struct {
+		Work    string `valid:"email=strict;email_domain=do1618.com,*.do1618.cn"`
+		Private string `valid:"email;email_not_domain=qq.com"`
+	}
+
+	tests := []struct {
+		Account Account
+		Expect  int
+	}{
+		{Account{"dave@do1618.com", "dave@163.com"}, 0},
+		{Account{"dave@mail.do1618.cn", "dave@163.com"}, 0},
+		{Account{"dave@do1618.cn", "dave@QQ.com"}, 2},
+		{Account{"dave@gmail.com", "dave@vip.qq.com"}, 2},
+	}
+
+	validor := NewValidation()
+	for i, test := range tests {
+		validor.Reset()
+		validor.Validate(test.Account)
+
+		if len(validor.Errs()) != test.Expect {
+			t.Errorf("case %d should got [%d] errors, but got %s", i, test.Expect, validor.ErrMsg())
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+type Base struct {
+	ID      string `valid:"required;uuid"`
+	Creator string `valid:"required"`
+}
+
+type audit struct {
+	Note string `valid:"required"`
+}
+
+type Inner struct {
+	Code string `valid:"required"`
+}
+
+type Middle struct {
+	Inner
+	Level int `valid:"required"`
+}
+
+func TestEmbeddedPromotion(t *testing.T) {
+	type User struct {
+		Base  `valid.Creator:"-" valid.ID:"required"`
+		audit // unexported type, promoted fields still checked
+		Name  string `valid:"required"`
+	}
+
+	validor := NewValidation()
+	validor.Validate(User{})
+
+	names := map[string]bool{}
+	for _, err := range validor.Errs() {
+		names[err.FieldName] = true
+	}
+
+	// ID overridden to required only, Creator disabled
+	if len(validor.Errs()) != 3 || !names["ID"] || !names["Note"] || !names["Name"] {
+		t.Errorf("should got errors on ID, Note and Name, but got %s", validor.ErrMsg())
+	}
+
+	validor.Reset()
+	validor.Validate(User{Base: Base{ID: "not-uuid"}, audit: audit{"n"}, Name: "dave"})
+	if validor.HasError() {
+		t.Errorf("overridden ID should skip uuid, but got %s", validor.ErrMsg())
+	}
+}
+
+func TestEmbeddedPtrAndPath(t *testing.T) {
+	type Doc struct {
+		*Middle `valid:"required"`
+		Base    `valid:"-"`
+		Code    string // hides Middle.Inner.Code
+	}
+
+	validor := NewValidation()
+	validor.Validate(Doc{})
+
+	// nil *Middle is required, its promoted fields are skipped
+	if len(validor.Errs()) != 1 || validor.Errs()[0].FieldName != "Middle" {
+		t.Errorf("should only got required error on Middle, but got %s", validor.ErrMsg())
+	}
+
+	validor.Reset()
+	validor.SetEmbeddedPath(true)
+	validor.Validate(&Doc{Middle: &Middle{}})
+
+	names := map[string]bool{}
+	for _, err := range validor.Errs() {
+		names[err.FieldName] = true
+	}
+
+	// Inner.Code is hidden by Doc.Code
+	if len(validor.Errs()) != 1 || !names["Middle.Level"] {
+		t.Errorf("should got error on Middle.Level, but got %s", validor.ErrMsg())
+	}
+
+	type Wrapper struct {
+		Middle
+	}
+
+	validor.Reset()
+	validor.Validate(Wrapper{})
+	if len(validor.Errs()) != 2 || validor.Errs()[0].FieldName != "Middle.Inner.Code" {
+		t.Errorf("should got errors on Middle.Inner.Code and Middle.Level, but got %s", validor.ErrMsg())
+	}
+}
+
+var (
+	errHookA  = errors.New("hook a")
+	errHookB  = errors.New("hook b")
+	errHookUp = errors.New("hook up")
+)
+
+type hookA struct{}
+
+func (hookA) Validater() error { return errHookA }
+
+type hookB struct{}
+
+func (hookB) Validater() error { return errHookB }
+
+// Promoted hook of hookA
+type HookPromoted struct {
+	hookA
+}
+
+// Outer hook hides hookA's one, like Go method
+type HookShadowed struct {
+	hookA
+}
+
+func (HookShadowed) Validater() error { return errHookUp }
+
+// Validater ambiguous, neither hook is promoted
+type HookAmbiguous struct {
+	hookA
+	hookB
+}
+
+func TestEmbeddedHooks(t *testing.T) {
+	tests := []struct {
+		Obj    interface{}
+		Expect []error
+	}{
+		{HookPromoted{}, []error{errHookA}},
+		{HookShadowed{}, []error{errHookUp}},
+		{HookAmbiguous{}, nil},
+	}
+
+	for _, test := range tests {
+		validor := NewValidation()
+		validor.Validate(test.Obj)
+
+		var got []error
+		for _, err := range validor.Errs() {
+			got = append(got, err.Err)
+		}
+
+		if len(got) != len(test.Expect) || (len(got) == 1 && got[0] != test.Expect[0]) {
+			t.Errorf("%T hooks should got %v, but got %v", test.Obj, test.Expect, got)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+// Package env load config struct from environment variables and check it by
+// valid tags. Problems are reported by variable names, all in one error:
+//
+//	type Config struct {
+//		Port    int           `env:"PORT" default:"8080" valid:"port"`
+//		Hosts   []string      `env:"HOSTS" valid:"required;hostname_rfc1123"`
+//		Timeout time.Duration `env:"TIMEOUT" default:"5s" valid:"min_duration=1s"`
+//		DB      DBConfig      `envPrefix:"DB_"`
+//	}
+//
+//	type DBConfig struct {
+//		URL string `env:"URL" valid:"required;url"` // DB_URL
+//	}
+//
+//	var cfg Config
+//	if err := env.Load(&cfg); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Empty variable is same as unset, default is used. Slices are split on
+// commas. Nil ptr of nested config is allocated only if one of its variables
+// is set, so it can be optional. Hooks of config types run too, fields without
+// env tag are reported by Go path, "Pool.Owner".
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/DavadDi/validation"
+	"github.com/DavadDi/validation/internal/decode"
+)
+
+// Tags of config fields
+const (
+	EnvTag       = "env"       // variable name, `env:"PORT"`
+	DefaultTag   = "default"   // value if unset, `default:"8080"`
+	EnvPrefixTag = "envPrefix" // prefix of nested struct, `envPrefix:"DB_"`
+
+	// Separator of slice values
+	SliceSeparator = ","
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Error all problems of config, FieldName of errors is variable name
+type Error struct {
+	Errors validation.Errors
+}
+
+// Error problems one in a line
+func (err *Error) Error() string {
+	msgs := []string{fmt.Sprintf("config has %d problems:", len(err.Errors))}
+	for _, e := range err.Errors {
+		msgs = append(msgs, fmt.Sprintf("\t%s: %s", e.FieldName, e.Err))
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Loader load config from variables, config it before use
+type Loader struct {
+	prefix string
+	lookup func(name string) (string, bool)
+}
+
+// NewLoader return Loader reading os environment without prefix
+func NewLoader() *Loader {
+	return &Loader{lookup: os.LookupEnv}
+}
+
+// Load fill obj from os environment by NewLoader
+func Load(obj interface{}) error {
+	return NewLoader().Load(obj)
+}
+
+// SetPrefix set prefix of all variables, "APP_" read APP_PORT for `env:"PORT"`
+func (l *Loader) SetPrefix(prefix string) {
+	l.prefix = prefix
+}
+
+// SetLookup replace os.LookupEnv, nil reset to it, tests can use a map
+func (l *Loader) SetLookup(fn func(name string) (string, bool)) {
+	if fn == nil {
+		fn = os.LookupEnv
+	}
+	l.lookup = fn
+}
+
+// Load fill fields of obj, which should be struct ptr, then validate it, hooks
+// of config types run too. Return *Error listing bad values and failed rules,
+// fields without env tag are reported by Go path, "DB.Pool".
+func (l *Loader) Load(obj interface{}) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("env need struct ptr, but got %T", obj)
+	}
+
+	var errs validation.Errors
+	var configs []config
+	l.loadStruct(config{p: v, prefix: l.prefix}, make(map[reflect.Type]bool), &configs, &errs)
+
+	// Check after all loaded, hooks may use nested configs
+	for _, c := range configs {
+		c.validate(&errs)
+	}
+
+	if len(errs) > 0 {
+		return &Error{Errors: errs}
+	}
+
+	return nil
+}
+
+// config struct ptr loaded, prefix is added to names of variables, gopath is
+// Go path of nested config, "DB"
+type config struct {
+	p      reflect.Value
+	prefix string
+	gopath string
+}
+
+// Fill fields of config c, it and nested configs are added to configs. Nil
+// ptr of nested config is allocated only if one of its variables is set. Types
+// in path are skipped, so self referential config is loaded once.
+func (l *Loader) loadStruct(c config, path map[reflect.Type]bool, configs *[]config, errs *validation.Errors) {
+	v, prefix := c.p.Elem(), c.prefix
+	*configs = append(*configs, c)
+
+	path[v.Type()] = true
+	defer delete(path, v.Type())
+
+	for _, tf := range reflect.VisibleFields(v.Type()) {
+		if len(tf.PkgPath) > 0 || tf.Anonymous {
+			continue
+		}
+
+		f, ok := decode.FieldByIndexAlloc(v, tf.Index)
+		if !ok {
+			continue
+		}
+
+		name, hasName := tf.Tag.Lookup(EnvTag)
+		if !hasName && isNested(tf.Type) {
+			if path[elemType(tf.Type)] {
+				continue
+			}
+
+			nested := prefix + tf.Tag.Get(EnvPrefixTag)
+			if f.Kind() == reflect.Ptr {
+				if f.IsNil() {
+					if !l.anySet(tf.Type.Elem(), nested, path) {
+						continue
+					}
+					f.Set(reflect.New(tf.Type.Elem()))
+				}
+				f = f.Elem()
+			}
+			l.loadStruct(config{p: f.Addr(), prefix: nested, gopath: joinPath(c.gopath, tf.Name)}, path, configs, errs)
+			continue
+		}
+
+		if name == "" || name == "-" {
+			continue
+		}
+		name = prefix + name
+
+		str, ok := l.lookup(name)
+		if !ok || str == "" {
+			str = tf.Tag.Get(DefaultTag)
+		}
+
+		if str != "" {
+			if err := setValue(f, str); err != nil {
+				*errs = append(*errs, &validation.Error{FieldName: name, Value: str, Err: err})
+			}
+		}
+	}
+}
+
+// Validate loaded config, hooks run too. Nested configs validate themselves,
+// only required of them is kept here.
+func (c config) validate(errs *validation.Errors) {
+	t := c.p.Elem().Type()
+
+	// Bad values are reported once, not again by rules
+	bad := make(map[string]bool)
+	for _, e := range *errs {
+		bad[e.FieldName] = true
+	}
+
+	for _, e := range validation.StructErrorsWithPath(c.p.Interface()) {
+		first, _, dotted := strings.Cut(e.FieldName, ".")
+		if tf, ok := t.FieldByName(first); ok && !hasEnvTag(tf) && isNested(tf.Type) &&
+			(dotted || e.Err != validation.ErrRequired) {
+			continue
+		}
+
+		name, ok := envName(t, e.FieldName, c.prefix)
+		switch {
+		case ok && bad[name]:
+			continue
+		case !ok && e.FieldName == "Object" && c.gopath != "":
+			// Hook of nested config
+			name = c.gopath
+		case !ok:
+			name = joinPath(c.gopath, e.FieldName)
+		}
+
+		var mismatch *validation.ErrFieldMismatch
+		if errors.As(e.Err, &mismatch) && mismatch.Field != "" {
+			if other, ok := envName(t, mismatch.Field, c.prefix); ok {
+				mismatch.Field = other
+			}
+		}
+
+		e.FieldName = name
+		*errs = append(*errs, e)
+	}
+}
+
+func hasEnvTag(tf reflect.StructField) bool {
+	_, ok := tf.Tag.Lookup(EnvTag)
+	return ok
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+// Return true if any variable of nested config type t is set
+func (l *Loader) anySet(t reflect.Type, prefix string, path map[reflect.Type]bool) bool {
+	path[t] = true
+	defer delete(path, t)
+
+	for _, tf := range reflect.VisibleFields(t) {
+		if len(tf.PkgPath) > 0 || tf.Anonymous {
+			continue
+		}
+
+		name, hasName := tf.Tag.Lookup(EnvTag)
+		if !hasName && isNested(tf.Type) {
+			if !path[elemType(tf.Type)] && l.anySet(elemType(tf.Type), prefix+tf.Tag.Get(EnvPrefixTag), path) {
+				return true
+			}
+			continue
+		}
+
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if str, ok := l.lookup(prefix + name); ok && str != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Struct without env tag is nested config
+func isNested(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(decode.TextUnmarshalerType)
+}
+
+// Return struct type of nested config field
+func elemType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+
+	return t
+}
+
+// Return variable name of field in struct t, false if it has no env tag
+func envName(t reflect.Type, field, prefix string) (string, bool) {
+	tf, ok := t.FieldByName(field)
+	if !ok {
+		return "", false
+	}
+
+	name := tf.Tag.Get(EnvTag)
+	if name == "" || name == "-" {
+		return "", false
+	}
+
+	return prefix + name, true
+}
+
+// Set field by string, slices are split on commas
+func setValue(v reflect.Value, str string) error {
+	if v.Kind() != reflect.Slice || v.Addr().Type().Implements(decode.TextUnmarshalerType) {
+		return decode.SetValues(v, []string{str})
+	}
+
+	var parts []string
+	for _, s := range strings.Split(str, SliceSeparator) {
+		if s = strings.TrimSpace(s); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	return decode.SetValues(v, parts)
+}
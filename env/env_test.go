@@ -0,0 +1,189 @@
+package env
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type DBConfig struct {
+	URL      string `env:"URL" valid:"required;url=postgres"`
+	MaxConns int    `env:"MAX_CONNS" default:"10"`
+}
+
+type Common struct {
+	Debug bool `env:"DEBUG"`
+}
+
+type Config struct {
+	Common
+
+	Port     int           `env:"PORT" default:"8080" valid:"port"`
+	Hosts    []string      `env:"HOSTS" valid:"required;hostname_rfc1123"`
+	Ports    []int         `env:"PORTS"`
+	Timeout  time.Duration `env:"TIMEOUT" default:"5s" valid:"min_duration=1s"`
+	Rate     *float64      `env:"RATE"`
+	Since    time.Time     `env:"SINCE"`
+	Addr     netip.Addr    `env:"ADDR"`
+	Password string        `env:"PASSWORD" valid:"required"`
+	Confirm  string        `env:"CONFIRM" valid:"eqfield=Password"`
+	DB       DBConfig      `envPrefix:"DB_"`
+	Cache    *DBConfig     `envPrefix:"CACHE_"`
+	Local    string
+	Skip     string `env:"-"`
+}
+
+func mapLookup(m map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		s, ok := m[name]
+		return s, ok
+	}
+}
+
+func TestLoad(t *testing.T) {
+	l := NewLoader()
+	l.SetPrefix("APP_")
+	l.SetLookup(mapLookup(map[string]string{
+		"APP_DEBUG":     "true",
+		"APP_HOSTS":     "a.example.com, b.example.com,",
+		"APP_PORTS":     "80,443",
+		"APP_TIMEOUT":   "",
+		"APP_RATE":      "0.5",
+		"APP_SINCE":     "2024-01-02T03:04:05Z",
+		"APP_ADDR":      "10.0.0.1",
+		"APP_PASSWORD":  "secret",
+		"APP_CONFIRM":   "secret",
+		"APP_DB_URL":    "postgres://db.example.com/app",
+		"APP_CACHE_URL": "postgres://cache.example.com/app",
+		"APP_SKIP":      "x",
+	}))
+
+	var got Config
+	if err := l.Load(&got); err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+
+	rate := 0.5
+	want := Config{
+		Common:   Common{Debug: true},
+		Port:     8080,
+		Hosts:    []string{"a.example.com", "b.example.com"},
+		Ports:    []int{80, 443},
+		Timeout:  5 * time.Second,
+		Rate:     &rate,
+		Since:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Addr:     netip.MustParseAddr("10.0.0.1"),
+		Password: "secret",
+		Confirm:  "secret",
+		DB:       DBConfig{URL: "postgres://db.example.com/app", MaxConns: 10},
+		Cache:    &DBConfig{URL: "postgres://cache.example.com/app", MaxConns: 10},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() got %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	l := NewLoader()
+	l.SetLookup(mapLookup(map[string]string{
+		"PORT":      "http",
+		"HOSTS":     "bad_host!",
+		"PORTS":     "80,x",
+		"TIMEOUT":   "100ms",
+		"CONFIRM":   "other",
+		"DB_URL":    "mysql://db/app",
+		"CACHE_URL": "postgres://cache/app",
+	}))
+
+	var cfg Config
+	err := l.Load(&cfg)
+
+	var ee *Error
+	if !errors.As(err, &ee) {
+		t.Fatalf("Load() = %v, want *Error", err)
+	}
+
+	want := []string{
+		"config has 7 problems:",
+		"\tPORT: should be int, but got [http]",
+		"\tPORTS: should be int, but got [x]",
+		"\tHOSTS: hostname format is not valid",
+		"\tTIMEOUT: duration should not be less than [1s]",
+		"\tPASSWORD: field can't be empty or zero",
+		"\tCONFIRM: value should be equal to field [PASSWORD]",
+		"\tDB_URL: url scheme is not allowed [mysql]",
+	}
+	if got := err.Error(); got != strings.Join(want, "\n") {
+		t.Errorf("Load() error:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+
+	if err := Load(cfg); err == nil {
+		t.Errorf("Load(struct) should fail, need ptr")
+	}
+}
+
+type Node struct {
+	Name string `env:"NAME" valid:"required"`
+	Next *Node  `envPrefix:"NEXT_"`
+}
+
+func TestLoadSelfReferential(t *testing.T) {
+	l := NewLoader()
+	l.SetLookup(mapLookup(map[string]string{"NAME": "a", "NEXT_NAME": "b"}))
+
+	var n Node
+	if err := l.Load(&n); err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+
+	if n.Name != "a" || n.Next != nil {
+		t.Errorf("Load() got %+v, want Next not loaded", n)
+	}
+}
+
+type PoolConfig struct {
+	Min int `env:"MIN"`
+	Max int `env:"MAX"`
+}
+
+func (p PoolConfig) Validater() error {
+	if p.Max < p.Min {
+		return errors.New("max should not be less than min")
+	}
+
+	return nil
+}
+
+type AppConfig struct {
+	Name  string      `env:"NAME" valid:"required"`
+	Cache *DBConfig   `envPrefix:"CACHE_"`
+	Pool  PoolConfig  `envPrefix:"POOL_"`
+	Owner string      `valid:"required"`
+	Extra *PoolConfig `envPrefix:"EXTRA_"`
+}
+
+func TestLoadNested(t *testing.T) {
+	l := NewLoader()
+	l.SetLookup(mapLookup(map[string]string{"NAME": "app", "POOL_MIN": "5", "POOL_MAX": "1", "EXTRA_MIN": "1"}))
+
+	var cfg AppConfig
+	err := l.Load(&cfg)
+
+	// Cache has no variable, it is optional and kept nil
+	if cfg.Cache != nil || cfg.Extra == nil || cfg.Extra.Min != 1 {
+		t.Errorf("Load() got Cache %v and Extra %v, want nil Cache", cfg.Cache, cfg.Extra)
+	}
+
+	want := []string{
+		"config has 3 problems:",
+		"\tOwner: field can't be empty or zero",
+		"\tPool: max should not be less than min",
+		"\tExtra: max should not be less than min",
+	}
+	if err == nil || err.Error() != strings.Join(want, "\n") {
+		t.Errorf("Load() error:\n%v\nwant:\n%s", err, strings.Join(want, "\n"))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // Error for Validater
@@ -14,6 +15,35 @@ var (
 	ErrValidater        = errors.New("validater should not be nil")
 	ErrValidaterNoFound = errors.New("validater not found")
 	ErrValidaterExists  = errors.New("validater exist")
+
+	ErrBadIPFormat       = errors.New("ip format is not valid")
+	ErrBadIPv4Format     = errors.New("ipv4 format is not valid")
+	ErrBadIPv6Format     = errors.New("ipv6 format is not valid")
+	ErrBadCIDRFormat     = errors.New("cidr format is not valid")
+	ErrNotPrivateIP      = errors.New("ip is not private")
+	ErrBadMACFormat      = errors.New("mac format is not valid")
+	ErrBadHostPortFormat = errors.New("host:port format is not valid")
+	ErrBadPortFormat     = errors.New("port should between [1-65535]")
+	ErrBadHostnameFormat = errors.New("hostname format is not valid")
+	ErrBadFQDNFormat     = errors.New("fqdn format is not valid")
+
+	ErrBadCountryCode = errors.New("country code is not valid ISO 3166-1 code")
+	ErrBadCurrency    = errors.New("currency is not valid ISO 4217 code")
+	ErrBadLanguageTag = errors.New("language is not well-formed BCP 47 tag")
+	ErrBadTimezone    = errors.New("timezone is not valid IANA name")
+
+	// Reasons of ErrBadURL
+	ErrURLLength     = errors.New("url is empty or too long")
+	ErrURLUnparsable = errors.New("url can't be parsed")
+	ErrURLNoScheme   = errors.New("url has no scheme")
+	ErrURLScheme     = errors.New("url scheme is not allowed")
+	ErrURLNoHost     = errors.New("url has no host")
+	ErrURLUserinfo   = errors.New("url userinfo is not allowed")
+	ErrURLIPHost     = errors.New("url ip host is not allowed")
+	ErrURLHost       = errors.New("url host is not allowed")
+
+	ErrSchemaNot   = errors.New("value should not match schema of not")
+	ErrSchemaFalse = errors.New("value is not allowed by schema false")
 )
 
 // Error for Validator, including filedname, value, err msg.
@@ -66,3 +96,278 @@ type ErrWrongExpectType struct {
 func (err *ErrWrongExpectType) Error() string {
 	return fmt.Sprintf("expect type %s, but got %T", err.ExpectType, err.PassValue)
 }
+
+// ErrBadPattern regex param in tag can't be compiled
+type ErrBadPattern struct {
+	Pattern string
+	Err     error
+}
+
+// ErrBadPattern detail error
+func (err *ErrBadPattern) Error() string {
+	return fmt.Sprintf("bad pattern [%s]: %s", err.Pattern, err.Err)
+}
+
+// ErrPatternMismatch value don't match pattern, or match it for not_regex
+type ErrPatternMismatch struct {
+	Pattern string
+	Negate  bool
+}
+
+// ErrPatternMismatch detail error
+func (err *ErrPatternMismatch) Error() string {
+	if err.Negate {
+		return fmt.Sprintf("value should not match pattern [%s]", err.Pattern)
+	}
+	return fmt.Sprintf("value should match pattern [%s]", err.Pattern)
+}
+
+// ErrTimeRange time or duration out of checker limit
+type ErrTimeRange struct {
+	Rule  string // checker name, such as "after"
+	Limit string // limit in tag, empty for past and future
+}
+
+// ErrTimeRange detail error
+func (err *ErrTimeRange) Error() string {
+	switch err.Rule {
+	case PastKey:
+		return "time should be in the past"
+	case FutureKey:
+		return "time should be in the future"
+	case WithinKey:
+		return fmt.Sprintf("time should be within [%s] from now", err.Limit)
+	case MinAgeKey:
+		return fmt.Sprintf("age should be at least [%s] years", err.Limit)
+	case MinDurationKey:
+		return fmt.Sprintf("duration should not be less than [%s]", err.Limit)
+	case MaxDurationKey:
+		return fmt.Sprintf("duration should not be more than [%s]", err.Limit)
+	}
+
+	return fmt.Sprintf("time should be %s [%s]", err.Rule, err.Limit)
+}
+
+// ErrIPNotInRange ip not in any range of ip_in
+type ErrIPNotInRange struct {
+	Ranges string
+}
+
+// ErrIPNotInRange detail error
+func (err *ErrIPNotInRange) Error() string {
+	return fmt.Sprintf("ip is not in range [%s]", err.Ranges)
+}
+
+// ErrBadIdentifier identifier such as uuid or semver malformed
+type ErrBadIdentifier struct {
+	Kind   string // checker name, such as "uuid4"
+	Reason string // which part is malformed
+}
+
+// ErrBadIdentifier detail error
+func (err *ErrBadIdentifier) Error() string {
+	return fmt.Sprintf("%s format is not valid: %s", err.Kind, err.Reason)
+}
+
+// ErrBadCreditCard card number malformed, Brand is empty if unknown
+type ErrBadCreditCard struct {
+	Brand  string
+	Reason string
+}
+
+// ErrBadCreditCard detail error, "Visa number is invalid: checksum failed"
+func (err *ErrBadCreditCard) Error() string {
+	brand := err.Brand
+	if brand == "" {
+		brand = "credit card"
+	}
+
+	return fmt.Sprintf("%s number is invalid: %s", brand, err.Reason)
+}
+
+// ErrBadPhone phone number malformed, Region is empty for e164 and phone without region
+type ErrBadPhone struct {
+	Region string
+	Reason string
+}
+
+// ErrBadPhone detail error
+func (err *ErrBadPhone) Error() string {
+	if err.Region == "" {
+		return "phone number is not valid: " + err.Reason
+	}
+
+	return fmt.Sprintf("phone number is not valid for %s: %s", err.Region, err.Reason)
+}
+
+// ErrBadEmail email rejected by email options, errors.Is ErrBadEmailFormat
+type ErrBadEmail struct {
+	Reason string
+}
+
+// ErrBadEmail detail error
+func (err *ErrBadEmail) Error() string {
+	return ErrBadEmailFormat.Error() + ": " + err.Reason
+}
+
+// Unwrap return ErrBadEmailFormat
+func (err *ErrBadEmail) Unwrap() error {
+	return ErrBadEmailFormat
+}
+
+// ErrUnresolvable host or email domain has no dns records
+type ErrUnresolvable struct {
+	Host string
+	Err  error // lookup error, nil if no records
+}
+
+// ErrUnresolvable detail error
+func (err *ErrUnresolvable) Error() string {
+	if err.Err == nil {
+		return fmt.Sprintf("host [%s] has no dns records", err.Host)
+	}
+
+	return fmt.Sprintf("host [%s] can't be resolved: %s", err.Host, err.Err)
+}
+
+// Unwrap return lookup error
+func (err *ErrUnresolvable) Unwrap() error {
+	return err.Err
+}
+
+// ErrBadURL url rejected by url checkers, errors.Is both ErrBadURLFormat and Reason
+type ErrBadURL struct {
+	Reason error  // such as ErrURLNoScheme
+	Detail string // scheme, host or parse error
+}
+
+// ErrBadURL detail error
+func (err *ErrBadURL) Error() string {
+	if err.Detail == "" {
+		return err.Reason.Error()
+	}
+
+	return fmt.Sprintf("%s [%s]", err.Reason, err.Detail)
+}
+
+// Unwrap return ErrBadURLFormat and Reason
+func (err *ErrBadURL) Unwrap() []error {
+	return []error{ErrBadURLFormat, err.Reason}
+}
+
+// ErrMaxDepth nested structs deeper than max depth
+type ErrMaxDepth struct {
+	Depth int
+	Type  reflect.Type
+}
+
+// ErrMaxDepth detail error
+func (err *ErrMaxDepth) Error() string {
+	return fmt.Sprintf("nested struct deeper than max depth %d at type %s", err.Depth, err.Type)
+}
+
+// ErrFieldMismatch value failed cross field checker
+type ErrFieldMismatch struct {
+	Rule  string // checker name, such as "eqfield"
+	Field string // other field in tag, empty for other value of ValidateVarWithValue
+}
+
+// ErrFieldMismatch detail error
+func (err *ErrFieldMismatch) Error() string {
+	op := map[string]string{
+		EqFieldKey:  "equal to",
+		NeFieldKey:  "not equal to",
+		GtFieldKey:  "greater than",
+		GteFieldKey: "greater than or equal to",
+		LtFieldKey:  "less than",
+		LteFieldKey: "less than or equal to",
+	}[err.Rule]
+
+	if err.Field == "" {
+		return fmt.Sprintf("value should be %s other value", op)
+	}
+	return fmt.Sprintf("value should be %s field [%s]", op, err.Field)
+}
+
+// ErrLength string length out of min_len or max_len
+type ErrLength struct {
+	Rule  string // checker name, such as "min_len"
+	Limit int
+}
+
+// ErrLength detail error
+func (err *ErrLength) Error() string {
+	if err.Rule == MinLenKey {
+		return fmt.Sprintf("length should not be less than [%d]", err.Limit)
+	}
+	return fmt.Sprintf("length should not be more than [%d]", err.Limit)
+}
+
+// ErrNotOneOf value not in oneof list
+type ErrNotOneOf struct {
+	Values []string
+}
+
+// ErrNotOneOf detail error
+func (err *ErrNotOneOf) Error() string {
+	return fmt.Sprintf("value should be one of [%s]", strings.Join(err.Values, ","))
+}
+
+// ErrNumberRange number out of minimum or maximum of JSON Schema
+type ErrNumberRange struct {
+	Keyword string // "minimum" or "maximum"
+	Limit   float64
+}
+
+// ErrNumberRange detail error
+func (err *ErrNumberRange) Error() string {
+	if err.Keyword == "minimum" {
+		return fmt.Sprintf("number should not be less than [%v]", err.Limit)
+	}
+	return fmt.Sprintf("number should not be more than [%v]", err.Limit)
+}
+
+// ErrRule bad rule of field found by Compile
+type ErrRule struct {
+	Field string // path of field, "User.Address.City"
+	Rule  string // checker name, empty for whole tag
+	Err   error
+}
+
+// ErrRule detail error
+func (err *ErrRule) Error() string {
+	if err.Rule == "" {
+		return fmt.Sprintf("[%s] %s", err.Field, err.Err)
+	}
+	return fmt.Sprintf("[%s] rule [%s]: %s", err.Field, err.Rule, err.Err)
+}
+
+func (err *ErrRule) Unwrap() error {
+	return err.Err
+}
+
+// ErrCompile all bad rules of struct type found by Compile
+type ErrCompile struct {
+	Type  reflect.Type
+	Rules []*ErrRule
+}
+
+// ErrCompile detail error, one rule in a line
+func (err *ErrCompile) Error() string {
+	msgs := make([]string, 0, len(err.Rules)+1)
+	msgs = append(msgs, fmt.Sprintf("compile %s failed with %d errors:", err.Type, len(err.Rules)))
+	for _, r := range err.Rules {
+		msgs = append(msgs, "\t"+r.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+func (err *ErrCompile) Unwrap() []error {
+	errs := make([]error, len(err.Rules))
+	for i, r := range err.Rules {
+		errs[i] = r
+	}
+
+	return errs
+}
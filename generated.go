@@ -0,0 +1,152 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Generated engine of Validate methods generated by cmd/validgen, set clock
+// or resolver on it before first use
+var Generated = NewValidation()
+
+// Validations for reflection fallback of generated code, config copied from Generated
+var generatedPool = sync.Pool{
+	New: func() interface{} {
+		mv := NewValidation()
+		mv.clock = Generated.clock
+		mv.resolver = Generated.resolver
+		mv.lookupTimeout = Generated.lookupTimeout
+		mv.maxDepth = Generated.maxDepth
+		return mv
+	},
+}
+
+// GeneratedValidater implemented by types with Validate methods generated by
+// cmd/validgen. ValidgenGenerated only marks them, so hand written Validate
+// method isn't taken for generated one.
+type GeneratedValidater interface {
+	Validate() error
+	ValidgenGenerated()
+}
+
+// Errors field errors returned by generated Validate methods
+type Errors []*Error
+
+// Error all errors in one line, same as ErrMsg
+func (errs Errors) Error() string {
+	buf := bytes.NewBufferString("")
+
+	for _, err := range errs {
+		buf.WriteString(err.String())
+	}
+
+	return buf.String()
+}
+
+// Rule one compiled rule of generated code
+type Rule struct {
+	r *rule
+}
+
+// NewRule compile one rule of tag, such as "regex=^[a-z]+$". Bad param is
+// returned by Check, rules without builder are looked up on every Check,
+// so custom rules added later are found.
+func NewRule(tag string) *Rule {
+	trs := parseTag(tag)
+	if len(trs) != 1 {
+		return &Rule{r: &rule{tagRule: tagRule{name: tag}, err: fmt.Errorf("need one rule, but got [%s]", tag)}}
+	}
+
+	return &Rule{r: Generated.compileRule(trs[0])}
+}
+
+// Check run rule on value, other is value of param field for cross field rules
+func (r *Rule) Check(v, other interface{}) error {
+	if r.r.cross != nil {
+		o, _ := otherValue(reflect.ValueOf(other), "")
+		return r.r.checkCross(v, o)
+	}
+
+	return Generated.checkRule(r.r, v, reflect.Value{})
+}
+
+// StructErrors validate struct by reflection, for nested struct without
+// generated method
+func StructErrors(obj interface{}) Errors {
+	mv := generatedPool.Get().(*Validation)
+	defer generatedPool.Put(mv)
+
+	mv.Reset()
+	mv.depth = 0
+	mv.Validate(obj)
+
+	return Errors(mv.Errs())
+}
+
+// StructErrorsWithPath like StructErrors, fields of nested structs are named
+// by path, "Address.City", see SetNestedPath
+func StructErrorsWithPath(obj interface{}) Errors {
+	mv := generatedPool.Get().(*Validation)
+	defer generatedPool.Put(mv)
+
+	mv.SetNestedPath(true)
+	defer mv.SetNestedPath(false)
+
+	mv.Reset()
+	mv.depth = 0
+	mv.Validate(obj)
+
+	return Errors(mv.Errs())
+}
+
+// CheckField check field by tag with reflection, for field types generated
+// code don't inline, such as map and interface. obj is the struct ptr for
+// cross field rules.
+func CheckField(name string, value interface{}, tag string, obj interface{}) Errors {
+	mv := generatedPool.Get().(*Validation)
+	defer generatedPool.Put(mv)
+
+	mv.Reset()
+	mv.depth = 0
+	mv.visited = make(map[visitKey]bool)
+
+	fp := mv.schemaPlan(tag, name)
+
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		if fp.required {
+			mv.addError(name, value, ErrRequired)
+		}
+		return Errors(mv.Errs())
+	}
+
+	o := reflect.ValueOf(obj)
+	for o.Kind() == reflect.Ptr && !o.IsNil() {
+		o = o.Elem()
+	}
+	mv.typeCheck(v, fp, o, false)
+
+	return Errors(mv.Errs())
+}
+
+// errorsReporter Reporter for StructValidater of generated code
+type errorsReporter struct {
+	errs *Errors
+	v    reflect.Value // struct value
+}
+
+// NewErrorsReporter return Reporter append to errs, obj is struct ptr
+func NewErrorsReporter(errs *Errors, obj interface{}) Reporter {
+	return &errorsReporter{errs: errs, v: reflect.Indirect(reflect.ValueOf(obj))}
+}
+
+// Report add err on field, nil err is ignored
+func (r *errorsReporter) Report(field string, err error) {
+	if err == nil {
+		return
+	}
+
+	*r.errs = append(*r.errs, &Error{FieldName: field, Value: fieldValue(r.v, field), Err: err})
+}
@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+var errBadItem = errors.New("bad item")
+
+// PtrItem Validater with pointer receiver
+type PtrItem struct {
+	Name string `valid:"required"`
+}
+
+func (p *PtrItem) Validater() error {
+	if p.Name == "bad" {
+		return errBadItem
+	}
+	return nil
+}
+
+// ValItem StructValidater with value receiver
+type ValItem struct {
+	Name string
+}
+
+func (v ValItem) ValidateStruct(r Reporter) {
+	if v.Name == "bad" {
+		r.Report("Name", errBadItem)
+	}
+}
+
+func TestHookReceivers(t *testing.T) {
+	type Order struct {
+		Items    []PtrItem  `valid:"required"`
+		Array    [2]PtrItem `valid:"required"`
+		PtrItems []*PtrItem `valid:"required"`
+		Ptr      *PtrItem   `valid:"required"`
+		Nested   PtrItem    `valid:"required"`
+		Values   []ValItem  `valid:"required"`
+		Value    ValItem    `valid:"required"`
+	}
+
+	bad := func() Order {
+		return Order{
+			Items:    []PtrItem{{"a"}, {"bad"}},
+			Array:    [2]PtrItem{{"bad"}, {"b"}},
+			PtrItems: []*PtrItem{{"bad"}},
+			Ptr:      &PtrItem{"bad"},
+			Nested:   PtrItem{"bad"},
+			Values:   []ValItem{{"bad"}},
+			Value:    ValItem{"bad"},
+		}
+	}
+
+	// Both addressable (ptr) and copied (value) traversal
+	for _, obj := range []interface{}{bad(), func() *Order { o := bad(); return &o }()} {
+		validor := NewValidation()
+		validor.Validate(obj)
+
+		n := 0
+		for _, err := range validor.Errs() {
+			if err.Err == errBadItem {
+				n++
+			}
+		}
+
+		if n != 7 {
+			t.Errorf("%T should got 7 hook errors, but got %s", obj, validor.ErrMsg())
+		}
+
+		// Object error value is struct value, not pointer made for hook
+		for _, err := range validor.Errs() {
+			if err.FieldName != "Object" {
+				continue
+			}
+
+			if item, ok := err.Value.(PtrItem); !ok || item.Name != "bad" {
+				t.Errorf("Object error value should be PtrItem, but got %T", err.Value)
+			}
+		}
+	}
+
+	// Top level value with pointer receiver
+	validor := NewValidation()
+	if validor.Validate(PtrItem{"bad"}) {
+		t.Errorf("pointer receiver Validater should be called for value")
+	}
+
+	validor.Reset()
+	if !validor.Validate((*PtrItem)(nil)) {
+		t.Errorf("nil ptr should be skipped, but got %s", validor.ErrMsg())
+	}
+}
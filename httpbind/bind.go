@@ -0,0 +1,239 @@
+// Package httpbind decode requests into structs and validate them.
+//
+// JSON and form bodies are decoded by content type, query parameters are
+// decoded into fields with query tag, same tag used by OpenAPI parameters:
+//
+//	type CreateUser struct {
+//		Name  string `json:"name" form:"name" valid:"required;min_len=2"`
+//		Email string `json:"email" form:"email" valid:"required;email"`
+//		Dry   bool   `query:"dry"`
+//	}
+//
+//	b := httpbind.NewBinder()
+//	http.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+//		var req CreateUser
+//		if !b.BindOrRender(w, r, &req) {
+//			return // 422 application/problem+json already written
+//		}
+//		...
+//	})
+package httpbind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/DavadDi/validation"
+)
+
+// Tags of fields decoded from url values
+const (
+	QueryTag = "query"
+	FormTag  = "form"
+
+	DefaultMaxBodySize = 1 << 20 // 1MB
+)
+
+// Renderer write error of Bind as response, err is *Error if binding failed
+type Renderer func(w http.ResponseWriter, r *http.Request, err error)
+
+// Binder decode and validate requests, config it before use
+type Binder struct {
+	maxBodySize   int64
+	disallowExtra bool
+	renderer      Renderer
+}
+
+// NewBinder return Binder with DefaultMaxBodySize and problem+json renderer
+func NewBinder() *Binder {
+	return &Binder{maxBodySize: DefaultMaxBodySize, renderer: WriteProblem}
+}
+
+// SetMaxBodySize set max bytes of request body, larger body get 413
+func (b *Binder) SetMaxBodySize(n int64) {
+	b.maxBodySize = n
+}
+
+// SetDisallowUnknownFields reject body with fields not in struct, query
+// parameters are not checked, they often have tracking params
+func (b *Binder) SetDisallowUnknownFields(on bool) {
+	b.disallowExtra = on
+}
+
+// SetRenderer replace WriteProblem, nil reset to WriteProblem
+func (b *Binder) SetRenderer(fn Renderer) {
+	if fn == nil {
+		fn = WriteProblem
+	}
+	b.renderer = fn
+}
+
+// Bind decode query and body of r into obj, which should be struct ptr, then
+// validate it. Return *Error with status 400, 413, 415 or 422.
+func (b *Binder) Bind(r *http.Request, obj interface{}) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("httpbind need struct ptr, but got %T", obj)
+	}
+
+	if _, err := decodeValues(r.URL.Query(), v.Elem(), QueryTag); err != nil {
+		return err
+	}
+
+	if err := b.decodeBody(r, v.Elem(), obj); err != nil {
+		return err
+	}
+
+	if errs := validate(obj); len(errs) > 0 {
+		return &Error{
+			Status: http.StatusUnprocessableEntity,
+			Detail: "validation failed",
+			Fields: fieldErrors(v.Elem().Type(), errs),
+			Err:    errs,
+		}
+	}
+
+	return nil
+}
+
+// BindOrRender bind r into obj, render error and return false if failed
+func (b *Binder) BindOrRender(w http.ResponseWriter, r *http.Request, obj interface{}) bool {
+	if err := b.Bind(r, obj); err != nil {
+		b.renderer(w, r, err)
+		return false
+	}
+
+	return true
+}
+
+type boundKey struct{}
+
+// Middleware bind every request into new object of newObj, next get it by
+// Bound. Failed request is rendered and next is not called.
+func (b *Binder) Middleware(newObj func() interface{}) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			obj := newObj()
+			if !b.BindOrRender(w, r, obj) {
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), boundKey{}, obj)))
+		})
+	}
+}
+
+// Bound return object bound by Middleware, nil if none
+func Bound(r *http.Request) interface{} {
+	return r.Context().Value(boundKey{})
+}
+
+// Decode body by content type, empty body is skipped
+func (b *Binder) decodeBody(r *http.Request, v reflect.Value, obj interface{}) error {
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		return nil
+	}
+
+	if b.maxBodySize > 0 {
+		r.Body = http.MaxBytesReader(nil, r.Body, b.maxBodySize)
+	}
+
+	ct, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch {
+	case err != nil && r.Header.Get("Content-Type") != "":
+		return &Error{Status: http.StatusUnsupportedMediaType, Detail: "bad content type", Err: err}
+	case ct == "" || ct == "application/json" || strings.HasSuffix(ct, "+json"):
+		return b.decodeJSON(r.Body, obj)
+	case ct == "application/x-www-form-urlencoded" || ct == "multipart/form-data":
+		return b.decodeForm(r, v)
+	}
+
+	return &Error{Status: http.StatusUnsupportedMediaType, Detail: fmt.Sprintf("unsupported content type [%s]", ct)}
+}
+
+func (b *Binder) decodeJSON(body io.Reader, obj interface{}) error {
+	dec := json.NewDecoder(body)
+	if b.disallowExtra {
+		dec.DisallowUnknownFields()
+	}
+
+	// Chunked body can be empty too
+	if err := dec.Decode(obj); err == io.EOF {
+		return nil
+	} else if err != nil {
+		return bodyError(err)
+	}
+
+	if dec.More() {
+		return &Error{Status: http.StatusBadRequest, Detail: "body should have one JSON value"}
+	}
+
+	return nil
+}
+
+func (b *Binder) decodeForm(r *http.Request, v reflect.Value) error {
+	var err error
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		err = r.ParseMultipartForm(b.maxBodySize)
+	} else {
+		err = r.ParseForm()
+	}
+
+	if err != nil {
+		return bodyError(err)
+	}
+
+	used, err := decodeValues(r.PostForm, v, FormTag)
+	if err != nil {
+		return err
+	}
+
+	if b.disallowExtra {
+		for name := range r.PostForm {
+			if !used[name] {
+				return &Error{Status: http.StatusBadRequest, Detail: fmt.Sprintf("unknown field [%s]", name)}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Return *Error for body decode error, 413 for body larger than limit
+func bodyError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return &Error{Status: http.StatusRequestEntityTooLarge, Detail: fmt.Sprintf("body larger than %d bytes", tooLarge.Limit), Err: err}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return &Error{
+			Status: http.StatusBadRequest,
+			Detail: "bad field value",
+			Fields: []FieldError{{Field: typeErr.Field, Message: fmt.Sprintf("should be %s, but got %s", typeErr.Type, typeErr.Value)}},
+			Err:    err,
+		}
+	}
+
+	return &Error{Status: http.StatusBadRequest, Detail: err.Error(), Err: err}
+}
+
+// Validate by Validate method generated by validgen if obj has one, else by
+// reflection. Hand written Validate method is not used, it may skip tags.
+// Errors of generated method have no path of nested fields, so failed object
+// is validated again by reflection for them.
+func validate(obj interface{}) validation.Errors {
+	if gv, ok := obj.(validation.GeneratedValidater); ok && gv.Validate() == nil {
+		return nil
+	}
+
+	return validation.StructErrorsWithPath(obj)
+}
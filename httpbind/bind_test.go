@@ -0,0 +1,325 @@
+package httpbind
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DavadDi/validation"
+)
+
+type BindAddress struct {
+	City string `json:"city" valid:"required"`
+}
+
+type CreateUser struct {
+	Name    string        `json:"name" form:"name" valid:"required;min_len=2"`
+	Email   string        `json:"email" form:"email" valid:"required;email"`
+	Age     int           `json:"age" form:"age"`
+	Tags    []string      `json:"tags" form:"tag"`
+	Address *BindAddress  `json:"address" valid:"required"`
+	Dry     bool          `query:"dry"`
+	Page    *int          `query:"page"`
+	Since   time.Time     `query:"since"`
+	Wait    time.Duration `query:"wait"`
+}
+
+func newRequest(method, target, contentType, body string) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+
+	return r
+}
+
+func TestBind(t *testing.T) {
+	b := NewBinder()
+
+	r := newRequest("POST", "/users?dry=true&page=2&since=2024-01-02T03:04:05Z&wait=3s&utm=x", "application/json",
+		`{"name":"dave","email":"dave@do1618.com","age":18,"tags":["a","b"],"address":{"city":"Wuhan"}}`)
+
+	var got CreateUser
+	if err := b.Bind(r, &got); err != nil {
+		t.Fatalf("Bind() = %v", err)
+	}
+
+	page := 2
+	want := CreateUser{
+		Name: "dave", Email: "dave@do1618.com", Age: 18, Tags: []string{"a", "b"},
+		Address: &BindAddress{City: "Wuhan"},
+		Dry:     true, Page: &page, Since: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Wait: 3 * time.Second,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Bind() got %+v, want %+v", got, want)
+	}
+
+	form := url.Values{"name": {"dave"}, "email": {"dave@do1618.com"}, "age": {"18"}, "tag": {"a", "b"}}
+	r = newRequest("POST", "/users", "application/x-www-form-urlencoded", form.Encode())
+
+	// Address can't be sent by form
+	got = CreateUser{Address: &BindAddress{City: "Wuhan"}}
+	if err := b.Bind(r, &got); err != nil {
+		t.Fatalf("Bind(form) = %v", err)
+	}
+
+	if got.Name != "dave" || got.Age != 18 || !reflect.DeepEqual(got.Tags, []string{"a", "b"}) {
+		t.Errorf("Bind(form) got %+v", got)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+		setup       func(b *Binder)
+		status      int
+		fields      []FieldError
+	}{
+		{
+			name: "invalid", target: "/users", contentType: "application/json",
+			body:   `{"name":"d","email":"dave","address":{}}`,
+			status: http.StatusUnprocessableEntity,
+			fields: []FieldError{
+				{Field: "name", Message: "length should not be less than [2]"},
+				{Field: "email", Message: "email format is not valid"},
+				{Field: "address.city", Message: "field can't be empty or zero"},
+			},
+		},
+		{
+			name: "empty body", target: "/users", status: http.StatusUnprocessableEntity,
+			fields: []FieldError{
+				{Field: "name", Message: "field can't be empty or zero"},
+				{Field: "name", Message: "length should not be less than [2]"},
+				{Field: "email", Message: "field can't be empty or zero"},
+				{Field: "email", Message: "email format is not valid"},
+				{Field: "address", Message: "field can't be empty or zero"},
+			},
+		},
+		{
+			name: "bad query", target: "/users?page=x&dry=maybe", status: http.StatusBadRequest,
+			fields: []FieldError{
+				{Field: "dry", Message: "should be bool, but got [maybe]"},
+				{Field: "page", Message: "should be int, but got [x]"},
+			},
+		},
+		{
+			name: "bad json type", target: "/users", contentType: "application/json",
+			body: `{"age":"18"}`, status: http.StatusBadRequest,
+			fields: []FieldError{{Field: "age", Message: "should be int, but got string"}},
+		},
+		{
+			name: "bad json", target: "/users", contentType: "application/json",
+			body: `{"age":`, status: http.StatusBadRequest,
+		},
+		{
+			name: "two values", target: "/users", contentType: "application/json",
+			body: `{} {}`, status: http.StatusBadRequest,
+		},
+		{
+			name: "unknown json field", target: "/users", contentType: "application/json",
+			body: `{"nick":"dave"}`, setup: func(b *Binder) { b.SetDisallowUnknownFields(true) },
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "unknown form field", target: "/users", contentType: "application/x-www-form-urlencoded",
+			body: "nick=dave", setup: func(b *Binder) { b.SetDisallowUnknownFields(true) },
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "too large", target: "/users", contentType: "application/json",
+			body: `{"name":"0123456789"}`, setup: func(b *Binder) { b.SetMaxBodySize(8) },
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "content type", target: "/users", contentType: "text/plain",
+			body: "dave", status: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		b := NewBinder()
+		if tt.setup != nil {
+			tt.setup(b)
+		}
+
+		var obj CreateUser
+		err := b.Bind(newRequest("POST", tt.target, tt.contentType, tt.body), &obj)
+
+		var be *Error
+		if !errors.As(err, &be) {
+			t.Errorf("%s: Bind() = %v, want *Error", tt.name, err)
+			continue
+		}
+
+		if be.Status != tt.status || tt.fields != nil && !reflect.DeepEqual(be.Fields, tt.fields) {
+			t.Errorf("%s: Bind() = %d %+v, want %d %+v", tt.name, be.Status, be.Fields, tt.status, tt.fields)
+		}
+	}
+
+	if err := NewBinder().Bind(newRequest("GET", "/", "", ""), CreateUser{}); err == nil {
+		t.Errorf("Bind(struct) should fail, need ptr")
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	h := NewBinder().Middleware(func() interface{} { return &CreateUser{} })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(Bound(r).(*CreateUser).Name))
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newRequest("POST", "/users", "application/json", `{"name":"dave","email":"dave","address":{"city":"Wuhan"}}`))
+
+	if w.Code != http.StatusUnprocessableEntity || w.Header().Get("Content-Type") != ProblemContentType {
+		t.Fatalf("response %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+
+	want := Problem{
+		Type: "about:blank", Title: "Unprocessable Entity", Status: 422, Detail: "validation failed", Instance: "/users",
+		Errors: []FieldError{{Field: "email", Message: "email format is not valid"}},
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("problem = %+v, want %+v", p, want)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRequest("POST", "/users", "application/json", `{"name":"dave","email":"dave@do1618.com","address":{"city":"Wuhan"}}`))
+	if w.Code != http.StatusOK || w.Body.String() != "dave" {
+		t.Errorf("response %d %q, want 200 dave", w.Code, w.Body.String())
+	}
+}
+
+func TestSetRenderer(t *testing.T) {
+	b := NewBinder()
+	b.SetRenderer(func(w http.ResponseWriter, r *http.Request, err error) {
+		http.Error(w, err.Error(), http.StatusTeapot)
+	})
+
+	w := httptest.NewRecorder()
+	if b.BindOrRender(w, newRequest("POST", "/users", "application/json", `{}`), &CreateUser{}) {
+		t.Fatalf("BindOrRender() = true, want false")
+	}
+
+	if w.Code != http.StatusTeapot || !strings.Contains(w.Body.String(), "[name] field can't be empty or zero") {
+		t.Errorf("response %d %q", w.Code, w.Body.String())
+	}
+}
+
+// HandValidate hand written Validate, tags are still checked
+type HandValidate struct {
+	Name string `json:"name" valid:"required"`
+}
+
+func (h *HandValidate) Validate() error {
+	return nil
+}
+
+// GenValidate Validate marked as generated by validgen, it pass to show it is
+// used instead of tags
+type GenValidate struct {
+	Name string `json:"name" valid:"required"`
+}
+
+func (g *GenValidate) Validate() error {
+	return nil
+}
+
+func (g *GenValidate) ValidgenGenerated() {}
+
+var _ validation.GeneratedValidater = (*GenValidate)(nil)
+
+func TestBindValidate(t *testing.T) {
+	tests := []struct {
+		obj     interface{}
+		message string
+	}{
+		{&HandValidate{}, "field can't be empty or zero"},
+		{&GenValidate{}, ""},
+	}
+
+	for _, test := range tests {
+		err := NewBinder().Bind(newRequest("POST", "/", "application/json", `{}`), test.obj)
+		if test.message == "" {
+			if err != nil {
+				t.Errorf("Bind(%T) = %v, want generated Validate used", test.obj, err)
+			}
+			continue
+		}
+
+		var be *Error
+		if !errors.As(err, &be) || be.Status != http.StatusUnprocessableEntity ||
+			len(be.Fields) != 1 || be.Fields[0].Message != test.message {
+			t.Errorf("Bind(%T) = %v, want 422 with [%s]", test.obj, err, test.message)
+		}
+	}
+}
+
+type BindStreet struct {
+	Name string `json:"street_name" valid:"required"`
+}
+
+// BindProfile nested structs have field with same Go name
+type BindProfile struct {
+	Name  string       `json:"name" valid:"required"`
+	Homes []BindStreet `json:"homes" valid:"required"`
+	Work  *BindStreet  `valid:"required"`
+}
+
+func TestBindNestedPath(t *testing.T) {
+	var got BindProfile
+	err := NewBinder().Bind(newRequest("POST", "/", "application/json", `{"homes": [{}], "Work": {}}`), &got)
+
+	want := []FieldError{
+		{Field: "name", Message: "field can't be empty or zero"},
+		{Field: "homes.street_name", Message: "field can't be empty or zero"},
+		{Field: "Work.street_name", Message: "field can't be empty or zero"},
+	}
+
+	var be *Error
+	if !errors.As(err, &be) || !reflect.DeepEqual(be.Fields, want) {
+		t.Errorf("Bind() = %v, want %+v", err, want)
+	}
+}
+
+type pageParams struct {
+	Page int `query:"page"`
+}
+
+// ListQuery page field promoted by nil ptr of private type, it can't be set
+type ListQuery struct {
+	*pageParams
+	Q string `query:"q"`
+}
+
+func TestBindUnexportedEmbedded(t *testing.T) {
+	var got ListQuery
+	if err := NewBinder().Bind(newRequest("GET", "/items?page=2&q=go", "", ""), &got); err != nil {
+		t.Fatalf("Bind() = %v", err)
+	}
+
+	if got.Q != "go" || got.pageParams != nil {
+		t.Errorf("Bind() got %+v, want only Q", got)
+	}
+
+	got = ListQuery{pageParams: &pageParams{}}
+	if err := NewBinder().Bind(newRequest("GET", "/items?page=2", "", ""), &got); err != nil {
+		t.Fatalf("Bind() = %v", err)
+	}
+
+	if got.Page != 2 {
+		t.Errorf("Bind() should set page of allocated embedded ptr, got %+v", got)
+	}
+}
@@ -0,0 +1,143 @@
+package httpbind
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/DavadDi/validation"
+)
+
+// ProblemContentType content type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// FieldError error of one field, field is the name in json, form or query tag
+type FieldError struct {
+	Field   string `json:"field,omitempty"` // empty for whole object, such as Validater hook
+	Message string `json:"message"`
+}
+
+// Error failed Bind, Status is status of response
+type Error struct {
+	Status int
+	Detail string
+	Fields []FieldError
+	Err    error // cause, validation.Errors for 422
+}
+
+// Error detail error
+func (err *Error) Error() string {
+	msgs := []string{http.StatusText(err.Status) + ": " + err.Detail}
+	for _, f := range err.Fields {
+		if f.Field == "" {
+			msgs = append(msgs, f.Message)
+		} else {
+			msgs = append(msgs, "["+f.Field+"] "+f.Message)
+		}
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// Problem RFC 7807 problem details, with errors of fields
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// NewProblem return problem of err, error not *Error is 500 without detail
+func NewProblem(r *http.Request, err error) *Problem {
+	p := &Problem{Type: "about:blank", Status: http.StatusInternalServerError}
+
+	if be, ok := err.(*Error); ok {
+		p.Status = be.Status
+		p.Detail = be.Detail
+		p.Errors = be.Fields
+	}
+
+	p.Title = http.StatusText(p.Status)
+	if r != nil {
+		p.Instance = r.URL.Path
+	}
+
+	return p
+}
+
+// WriteProblem default Renderer, write err as application/problem+json
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	p := NewProblem(r, err)
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// Convert validation errors to field errors, field names are dotted paths of
+// json names, form or query names for fields without json tag
+func fieldErrors(t reflect.Type, errs validation.Errors) []FieldError {
+	out := make([]FieldError, 0, len(errs))
+	for _, e := range errs {
+		var name string
+		if e.FieldName != "Object" {
+			name = wirePath(t, e.FieldName)
+		}
+		out = append(out, FieldError{Field: name, Message: e.Err.Error()})
+	}
+
+	return out
+}
+
+// Return path of names in request for Go path, "address.city" for
+// "Address.City". Unknown names are kept.
+func wirePath(t reflect.Type, path string) string {
+	parts := strings.Split(path, ".")
+
+	for i, name := range parts {
+		st := elemType(t)
+		if st.Kind() != reflect.Struct {
+			break
+		}
+
+		tf, ok := st.FieldByName(name)
+		if !ok {
+			break
+		}
+
+		parts[i] = wireName(tf)
+		t = tf.Type
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// Name of field in json, form or query tag, Go name if none
+func wireName(tf reflect.StructField) string {
+	for _, tag := range []string{"json", FormTag, QueryTag} {
+		if name := tagName(tf, tag); name != "" {
+			return name
+		}
+	}
+
+	return tf.Name
+}
+
+// Return element type of pointer, slice, array and map
+func elemType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package httpbind
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/DavadDi/validation/internal/decode"
+)
+
+// Return name in tag, "page" for `query:"page,omitempty"`, empty for "-"
+func tagName(tf reflect.StructField, tag string) string {
+	name, _, _ := strings.Cut(tf.Tag.Get(tag), ",")
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+// Decode values into fields with tag, fields of embedded structs are
+// promoted. Return names used by fields, bad values are reported together.
+func decodeValues(values url.Values, v reflect.Value, tag string) (map[string]bool, error) {
+	used := make(map[string]bool)
+
+	var fields []FieldError
+	for _, tf := range reflect.VisibleFields(v.Type()) {
+		if len(tf.PkgPath) > 0 || tf.Anonymous {
+			continue
+		}
+
+		name := tagName(tf, tag)
+		if name == "" {
+			continue
+		}
+		used[name] = true
+
+		vals := values[name]
+		if len(vals) == 0 {
+			continue
+		}
+
+		f, ok := decode.FieldByIndexAlloc(v, tf.Index)
+		if !ok {
+			continue
+		}
+
+		if err := decode.SetValues(f, vals); err != nil {
+			fields = append(fields, FieldError{Field: name, Message: err.Error()})
+		}
+	}
+
+	if len(fields) > 0 {
+		return used, &Error{Status: http.StatusBadRequest, Detail: "bad field value", Fields: fields}
+	}
+
+	return used, nil
+}
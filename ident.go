@@ -0,0 +1,144 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Identifier checker names
+const (
+	UUIDKey        = "uuid"
+	UUID4Key       = "uuid4"
+	UUID7Key       = "uuid7"
+	ULIDKey        = "ulid"
+	KSUIDKey       = "ksuid"
+	SemverKey      = "semver"
+	SemverRangeKey = "semver_range"
+
+	uuidLen  = 36
+	ulidLen  = 26
+	ksuidLen = 27
+
+	// Crockford base32 used by ULID, without I L O U
+	ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// Base62 used by KSUID, ascii order
+	ksuidAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// Max KSUID, 20 bytes all 0xff
+	ksuidMax = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+)
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// Check canonical form 8-4-4-4-12, version 0 accept any version
+func checkUUID(kind string, str string, version byte) error {
+	if len(str) != uuidLen {
+		return &ErrBadIdentifier{Kind: kind, Reason: fmt.Sprintf("length should be %d, got %d", uuidLen, len(str))}
+	}
+
+	for i := 0; i < len(str); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if str[i] != '-' {
+				return &ErrBadIdentifier{Kind: kind, Reason: fmt.Sprintf("expect '-' at position %d", i)}
+			}
+		default:
+			if !isHex(str[i]) {
+				return &ErrBadIdentifier{Kind: kind, Reason: fmt.Sprintf("bad hex char %q at position %d", str[i], i)}
+			}
+		}
+	}
+
+	if version == 0 {
+		return nil
+	}
+
+	// Version nibble is first char of third group
+	if str[14] != version {
+		return &ErrBadIdentifier{Kind: kind, Reason: fmt.Sprintf("version nibble should be %c, got %c", version, str[14])}
+	}
+
+	// RFC 4122 variant is 10xx, first char of forth group
+	if !strings.ContainsRune("89abAB", rune(str[19])) {
+		return &ErrBadIdentifier{Kind: kind, Reason: fmt.Sprintf("variant nibble should be one of [89ab], got %c", str[19])}
+	}
+
+	return nil
+}
+
+func uuidChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	return checkUUID(UUIDKey, str, 0)
+}
+
+func uuid4Checker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	return checkUUID(UUID4Key, str, '4')
+}
+
+func uuid7Checker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	return checkUUID(UUID7Key, str, '7')
+}
+
+func ulidChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	if len(str) != ulidLen {
+		return &ErrBadIdentifier{Kind: ULIDKey, Reason: fmt.Sprintf("length should be %d, got %d", ulidLen, len(str))}
+	}
+
+	upper := strings.ToUpper(str)
+	for i := 0; i < len(upper); i++ {
+		if strings.IndexByte(ulidAlphabet, upper[i]) < 0 {
+			return &ErrBadIdentifier{Kind: ULIDKey, Reason: fmt.Sprintf("bad base32 char %q at position %d", str[i], i)}
+		}
+	}
+
+	// 26 chars hold 130 bits, timestamp overflow 128 bits if first char > 7
+	if upper[0] > '7' {
+		return &ErrBadIdentifier{Kind: ULIDKey, Reason: "timestamp overflow, first char should be in [0-7]"}
+	}
+
+	return nil
+}
+
+func ksuidChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	if len(str) != ksuidLen {
+		return &ErrBadIdentifier{Kind: KSUIDKey, Reason: fmt.Sprintf("length should be %d, got %d", ksuidLen, len(str))}
+	}
+
+	for i := 0; i < len(str); i++ {
+		if strings.IndexByte(ksuidAlphabet, str[i]) < 0 {
+			return &ErrBadIdentifier{Kind: KSUIDKey, Reason: fmt.Sprintf("bad base62 char %q at position %d", str[i], i)}
+		}
+	}
+
+	// Same length and ascii ordered alphabet, compare as string
+	if str > ksuidMax {
+		return &ErrBadIdentifier{Kind: KSUIDKey, Reason: "value overflow 160 bits"}
+	}
+
+	return nil
+}
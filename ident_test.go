@@ -0,0 +1,65 @@
+package validation
+
+import "testing"
+
+func TestIdentCheckers(t *testing.T) {
+	tests := []struct {
+		Checker ValidaterFunc
+		Value   string
+		Expect  bool
+	}{
+		{uuidChecker, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", true},
+		{uuidChecker, "6ba7b8109dad11d180b400c04fd430c8", false},
+		{uuidChecker, "6ba7b810-9dad-11d1-80b4-00c04fd430cg", false},
+		{uuid4Checker, "f47ac10b-58cc-4372-a567-0e02b2c3d479", true},
+		{uuid4Checker, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", false},
+		{uuid4Checker, "f47ac10b-58cc-4372-c567-0e02b2c3d479", false},
+		{uuid7Checker, "01890a5d-ac96-774b-bcce-b302099a8057", true},
+		{uuid7Checker, "f47ac10b-58cc-4372-a567-0e02b2c3d479", false},
+		{ulidChecker, "01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{ulidChecker, "01arz3ndektsv4rrffq69g5fav", true},
+		{ulidChecker, "81ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+		{ulidChecker, "01ARZ3NDEKTSV4RRFFQ69G5FAU", false},
+		{ksuidChecker, "0ujtsYcgvSTl8PAuAdqWYSMnLOv", true},
+		{ksuidChecker, "aWgEPTl1tmebfsQzFP4bxwgy80V", true},
+		{ksuidChecker, "zzzzzzzzzzzzzzzzzzzzzzzzzzz", false},
+		{ksuidChecker, "0ujtsYcgvSTl8PAuAdqWYSMnLO", false},
+		{semverChecker, "1.2.3", true},
+		{semverChecker, "1.0.0-alpha.1+build.5", true},
+		{semverChecker, "1.0.0-0A.is.legal", true},
+		{semverChecker, "1.2", false},
+		{semverChecker, "01.2.3", false},
+		{semverChecker, "1.2.3-alpha..1", false},
+		{semverChecker, "1.2.3-01", false},
+		{semverChecker, "1.2.3+build_1", false},
+		{semverRangeChecker, ">=1.2.3 <2.0.0 || ^3.1", true},
+		{semverRangeChecker, ">= 1.2.3", true},
+		{semverRangeChecker, "1.x || ~2.3.0-beta.1", true},
+		{semverRangeChecker, "1.0.0 - 1.2", true},
+		{semverRangeChecker, "*", true},
+		{semverRangeChecker, "1.x.3", false},
+		{semverRangeChecker, "1.2.3 ||", false},
+		{semverRangeChecker, ">=", false},
+		{semverRangeChecker, "", false},
+	}
+
+	for i, test := range tests {
+		err := test.Checker(test.Value)
+		if (err == nil) != test.Expect {
+			t.Errorf("case %d [%s] should [%t], got err %v", i, test.Value, test.Expect, err)
+		}
+	}
+}
+
+func TestIdentErrorReason(t *testing.T) {
+	err := uuid4Checker("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	if err == nil || err.Error() != "uuid4 format is not valid: version nibble should be 4, got 1" {
+		t.Errorf("got unexpected error %v", err)
+	}
+
+	err = semverChecker("1.2.3-beta.01")
+	bad, ok := err.(*ErrBadIdentifier)
+	if !ok || bad.Kind != SemverKey {
+		t.Errorf("should got ErrBadIdentifier for semver, but got %v", err)
+	}
+}
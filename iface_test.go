@@ -0,0 +1,65 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+var errBadRadius = errors.New("radius should be positive")
+
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct {
+	Name   string `valid:"required"`
+	Radius float64
+}
+
+func (c *Circle) Area() float64 { return 3.14 * c.Radius * c.Radius }
+
+func (c *Circle) Validater() error {
+	if c.Radius <= 0 {
+		return errBadRadius
+	}
+	return nil
+}
+
+type Square struct {
+	Name string `valid:"required;regex=^sq"`
+	Side float64
+}
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+func TestInterfaceField(t *testing.T) {
+	type Drawing struct {
+		Main   Shape       `valid:"required"`
+		Shapes []Shape     `valid:"required"`
+		Label  interface{} `valid:"required;email"`
+		Extra  interface{} `valid:"url"`
+	}
+
+	tests := []struct {
+		Drawing Drawing
+		Expect  int
+	}{
+		{Drawing{&Circle{"c", 1}, []Shape{Square{"sq", 1}, &Circle{"c", 2}}, "a@do1618.com", nil}, 0},
+		// Main nil, Label nil
+		{Drawing{nil, []Shape{Square{"sq", 1}}, nil, nil}, 2},
+		// Circle without name and radius, Square with bad name
+		{Drawing{&Circle{}, []Shape{Square{"bad", 1}}, "a@do1618.com", nil}, 3},
+		// Label is not email, Extra is not url, nil element in slice
+		{Drawing{Square{"sq", 1}, []Shape{nil}, "dave", "www"}, 3},
+	}
+
+	validor := NewValidation()
+	for i, test := range tests {
+		validor.Reset()
+		validor.Validate(&test.Drawing)
+
+		if len(validor.Errs()) != test.Expect {
+			t.Errorf("case %d should got [%d] errors, but got %s", i, test.Expect, validor.ErrMsg())
+		}
+	}
+}
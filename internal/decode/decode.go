@@ -0,0 +1,121 @@
+// Package decode set struct fields from strings, it is shared by httpbind and
+// env.
+package decode
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Types set specially, not by kind
+var (
+	DurationType        = reflect.TypeOf(time.Duration(0))
+	TextUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// FieldByIndexAlloc like reflect.Value.FieldByIndex, nil embedded ptr is
+// allocated, false for embedded ptr of private type which can't be set
+func FieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+// SetValues set field by values, slice get all values, others get the first
+// one. Empty vals is ignored.
+func SetValues(v reflect.Value, vals []string) error {
+	if len(vals) == 0 {
+		return nil
+	}
+
+	switch {
+	case v.Kind() == reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if err := SetValues(elem.Elem(), vals); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+
+	case v.Kind() == reflect.Slice && !v.Addr().Type().Implements(TextUnmarshalerType):
+		s := reflect.MakeSlice(v.Type(), len(vals), len(vals))
+		for i, str := range vals {
+			if err := SetValues(s.Index(i), []string{str}); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	}
+
+	return SetScalar(v, vals[0])
+}
+
+// SetScalar set single value, types with UnmarshalText such as time.Time
+// parse itself
+func SetScalar(v reflect.Value, str string) error {
+	if tu, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(str))
+	}
+
+	if v.Type() == DurationType {
+		d, err := time.ParseDuration(str)
+		if err != nil {
+			return fmt.Errorf("should be duration, but got [%s]", str)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(str)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return fmt.Errorf("should be bool, but got [%s]", str)
+		}
+		v.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(str, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("should be %s, but got [%s]", v.Kind(), str)
+		}
+		v.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(str, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("should be %s, but got [%s]", v.Kind(), str)
+		}
+		v.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(str, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("should be %s, but got [%s]", v.Kind(), str)
+		}
+		v.SetFloat(f)
+
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,382 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONSchemaDraft dialect of generated JSON Schema
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema document of draft 2020-12, only keywords mapped from valid tags
+// and checked by ValidateJSONSchema
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 JSONType               `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Not                  *JSONSchema            `json:"not,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// UnmarshalJSON decode schema object, or boolean schema in any position,
+// true is {} and false is {"not": {}}
+func (s *JSONSchema) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		*s = JSONSchema{}
+		if !b {
+			s.Not = &JSONSchema{}
+		}
+		return nil
+	}
+
+	// Without methods, decode fields by default
+	type plain JSONSchema
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// Schema false, {"not": {}}
+func (s *JSONSchema) isFalse() bool {
+	return s.Not != nil && reflect.DeepEqual(*s.Not, JSONSchema{})
+}
+
+// JSONType type keyword, one type name or list such as ["string", "null"]
+type JSONType []string
+
+// MarshalJSON encode single type as string
+func (t JSONType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON decode string or list of strings
+func (t *JSONType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = JSONType{name}
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*t = names
+
+	return nil
+}
+
+// Formats of checkers, other checkers have no JSON Schema keyword
+var jsonSchemaFormats = map[string]string{
+	EmailKey:      "email",
+	URLKey:        "uri",
+	URLSchemesKey: "uri",
+	URIKey:        "uri",
+	HTTPURLKey:    "uri",
+	IPv4Key:       "ipv4",
+	IPv6Key:       "ipv6",
+	HostnameKey:   "hostname",
+	FQDNKey:       "hostname",
+	UUIDKey:       "uuid",
+	UUID4Key:      "uuid",
+	UUID7Key:      "uuid",
+}
+
+// JSONSchemaOf return JSON Schema of struct by valid tags, property names are
+// json tag names. Nested structs are in "$defs", rules of slice and map are
+// for their elements, same as Validate.
+//
+// Required string is also "minLength": 1. Required number and bool only need
+// the key, zero value pass schema but fail Validate.
+//
+//	schema, err := validation.JSONSchemaOf(User{})
+//	data, err := json.MarshalIndent(schema, "", "  ")
+func JSONSchemaOf(obj interface{}) (*JSONSchema, error) {
+	t, err := structType(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newSchemaGen(jsonSchemaDefs)
+	g.refs[t] = "#"
+
+	s := g.object(t)
+	s.Schema = JSONSchemaDraft
+	s.Title = t.Name()
+	if len(g.defs) > 0 {
+		s.Defs = g.defs
+	}
+
+	return s, nil
+}
+
+// Return struct type of obj
+func structType(obj interface{}) (reflect.Type, error) {
+	t := reflect.TypeOf(obj)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil {
+		return nil, fmt.Errorf("need struct, but got nil")
+	}
+
+	if t.Kind() != reflect.Struct || isValueStruct(t) {
+		return nil, &ErrOnlyStrcut{Type: t}
+	}
+
+	return t, nil
+}
+
+// Prefix of "$ref" to struct in "$defs"
+const jsonSchemaDefs = "#/$defs/"
+
+// schemaGen state of one JSONSchemaOf, struct types are generated once
+type schemaGen struct {
+	prefix string // prefix of "$ref", named struct is appended
+	defs   map[string]*JSONSchema
+	refs   map[reflect.Type]string
+}
+
+func newSchemaGen(prefix string) *schemaGen {
+	return &schemaGen{
+		prefix: prefix,
+		defs:   make(map[string]*JSONSchema),
+		refs:   make(map[reflect.Type]string),
+	}
+}
+
+// Return object schema of struct fields, fields of embedded structs are promoted
+func (g *schemaGen) object(t reflect.Type) *JSONSchema {
+	s := &JSONSchema{Type: JSONType{"object"}, Properties: make(map[string]*JSONSchema)}
+
+	for _, tf := range reflect.VisibleFields(t) {
+		if len(tf.PkgPath) > 0 || isEmbeddedStruct(tf) {
+			continue
+		}
+
+		tag, _, ok := promotedTag(t, tf)
+		if !ok {
+			continue
+		}
+
+		name, ok := jsonName(tf)
+		if !ok {
+			continue
+		}
+
+		rules, required := splitRequired(parseTag(tag))
+
+		ps := g.value(tf.Type, rules)
+		if required {
+			s.Required = append(s.Required, name)
+			if tf.Type.Kind() == reflect.String && (ps.MinLength == nil || *ps.MinLength < 1) {
+				one := 1
+				ps.MinLength = &one
+			}
+		} else if isNilable(tf.Type) {
+			// Encoded as null when nil
+			ps = nullable(ps)
+		}
+		s.Properties[name] = ps
+	}
+
+	return s
+}
+
+func isNilable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return true
+	}
+
+	return false
+}
+
+// Return schema allow null too
+func nullable(s *JSONSchema) *JSONSchema {
+	switch {
+	case s.Ref != "":
+		return &JSONSchema{AnyOf: []*JSONSchema{s, {Type: JSONType{"null"}}}}
+	case len(s.Type) > 0:
+		s.Type = append(s.Type, "null")
+		if len(s.Enum) > 0 {
+			s.Enum = append(s.Enum, nil)
+		}
+	}
+
+	return s
+}
+
+// Split required from other rules
+func splitRequired(trs []tagRule) ([]tagRule, bool) {
+	var rules []tagRule
+	required := false
+	for _, tr := range trs {
+		if tr.name == RequiredKey {
+			required = true
+			continue
+		}
+		rules = append(rules, tr)
+	}
+
+	return rules, required
+}
+
+// Return name of field in JSON, false for `json:"-"`
+func jsonName(tf reflect.StructField) (string, bool) {
+	tag := tf.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+
+	return tf.Name, true
+}
+
+// Return schema of value type with rules
+func (g *schemaGen) value(t reflect.Type, rules []tagRule) *JSONSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &JSONSchema{Type: JSONType{"string"}, Format: "date-time"}
+	}
+
+	var typ string
+
+	switch t.Kind() {
+	case reflect.Bool:
+		typ = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		typ = "integer"
+	case reflect.Float32, reflect.Float64:
+		typ = "number"
+	case reflect.String:
+		typ = "string"
+	case reflect.Slice, reflect.Array:
+		// []byte is base64 string in JSON
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &JSONSchema{Type: JSONType{"string"}}
+		}
+		return &JSONSchema{Type: JSONType{"array"}, Items: g.value(t.Elem(), rules)}
+	case reflect.Map:
+		return &JSONSchema{Type: JSONType{"object"}, AdditionalProperties: g.value(t.Elem(), rules)}
+	case reflect.Struct:
+		return g.ref(t)
+	}
+
+	// Interface and others can be any value
+	s := &JSONSchema{}
+	if typ != "" {
+		s.Type = JSONType{typ}
+	}
+
+	for _, tr := range rules {
+		g.applyRule(s, typ, tr)
+	}
+
+	return s
+}
+
+// Return "$ref" to struct in "$defs", anonymous struct is inlined
+func (g *schemaGen) ref(t reflect.Type) *JSONSchema {
+	if ref, ok := g.refs[t]; ok {
+		return &JSONSchema{Ref: ref}
+	}
+
+	if t.Name() == "" {
+		return g.object(t)
+	}
+
+	// Same name in other pkg
+	name := t.Name()
+	for i := 2; g.defs[name] != nil; i++ {
+		name = fmt.Sprintf("%s%d", t.Name(), i)
+	}
+
+	ref := g.prefix + name
+	g.refs[t] = ref
+	g.defs[name] = &JSONSchema{}
+	*g.defs[name] = *g.object(t)
+
+	return &JSONSchema{Ref: ref}
+}
+
+// Map rule to keyword, bad params are reported by Validate, not here
+func (g *schemaGen) applyRule(s *JSONSchema, typ string, tr tagRule) {
+	if format, ok := jsonSchemaFormats[tr.name]; ok {
+		s.Format = format
+		return
+	}
+
+	switch tr.name {
+	case MinLenKey:
+		if n, err := strconv.Atoi(tr.param); err == nil {
+			s.MinLength = &n
+		}
+	case MaxLenKey:
+		if n, err := strconv.Atoi(tr.param); err == nil {
+			s.MaxLength = &n
+		}
+	case RegexKey:
+		s.Pattern = patternSource(tr.param)
+	case NotRegexKey:
+		s.Not = &JSONSchema{Pattern: patternSource(tr.param)}
+	case OneOfKey:
+		for _, v := range oneOfValues(tr.param) {
+			s.Enum = append(s.Enum, enumValue(typ, v))
+		}
+	}
+}
+
+// Return pattern added by RegisterPattern for "@name", or param itself
+func patternSource(param string) string {
+	if name, ok := patternName(param); ok {
+		if rx, ok := findPattern(name); ok {
+			return rx.String()
+		}
+	}
+
+	return param
+}
+
+// Return oneof value as JSON type of field
+func enumValue(typ, v string) interface{} {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,169 @@
+package validation
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type SchemaAddress struct {
+	City string `json:"city" valid:"required;max_len=64"`
+	Zip  string `json:"zip" valid:"regex=^[0-9]{6}$"`
+}
+
+type SchemaBase struct {
+	ID string `json:"id" valid:"required;uuid"`
+}
+
+type SchemaUser struct {
+	SchemaBase
+	Name     string            `json:"name" valid:"required;min_len=2;max_len=32"`
+	Email    string            `json:"email,omitempty" valid:"email"`
+	Homepage string            `json:"homepage" valid:"url"`
+	Role     string            `json:"role" valid:"oneof=admin,user"`
+	Level    int               `json:"level" valid:"oneof=1,2,3"`
+	Tags     []string          `json:"tags" valid:"not_regex=^admin"`
+	Address  *SchemaAddress    `json:"address" valid:"required"`
+	Billing  *SchemaAddress    `json:"billing"`
+	Labels   map[string]string `json:"labels"`
+	Friends  []*SchemaUser     `json:"friends"`
+	Created  time.Time         `json:"created"`
+	Secret   string            `json:"-" valid:"required"`
+	internal string
+}
+
+const schemaUserJSON = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "SchemaUser",
+  "type": "object",
+  "properties": {
+    "address": {
+      "$ref": "#/$defs/SchemaAddress"
+    },
+    "billing": {
+      "anyOf": [
+        {
+          "$ref": "#/$defs/SchemaAddress"
+        },
+        {
+          "type": "null"
+        }
+      ]
+    },
+    "created": {
+      "type": "string",
+      "format": "date-time"
+    },
+    "email": {
+      "type": "string",
+      "format": "email"
+    },
+    "friends": {
+      "type": [
+        "array",
+        "null"
+      ],
+      "items": {
+        "$ref": "#"
+      }
+    },
+    "homepage": {
+      "type": "string",
+      "format": "uri"
+    },
+    "id": {
+      "type": "string",
+      "format": "uuid",
+      "minLength": 1
+    },
+    "labels": {
+      "type": [
+        "object",
+        "null"
+      ],
+      "additionalProperties": {
+        "type": "string"
+      }
+    },
+    "level": {
+      "type": "integer",
+      "enum": [
+        1,
+        2,
+        3
+      ]
+    },
+    "name": {
+      "type": "string",
+      "minLength": 2,
+      "maxLength": 32
+    },
+    "role": {
+      "type": "string",
+      "enum": [
+        "admin",
+        "user"
+      ]
+    },
+    "tags": {
+      "type": [
+        "array",
+        "null"
+      ],
+      "items": {
+        "type": "string",
+        "not": {
+          "pattern": "^admin"
+        }
+      }
+    }
+  },
+  "required": [
+    "id",
+    "name",
+    "address"
+  ],
+  "$defs": {
+    "SchemaAddress": {
+      "type": "object",
+      "properties": {
+        "city": {
+          "type": "string",
+          "minLength": 1,
+          "maxLength": 64
+        },
+        "zip": {
+          "type": "string",
+          "pattern": "^[0-9]{6}$"
+        }
+      },
+      "required": [
+        "city"
+      ]
+    }
+  }
+}`
+
+func TestJSONSchemaOf(t *testing.T) {
+	schema, err := JSONSchemaOf(&SchemaUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != schemaUserJSON {
+		t.Errorf("JSONSchemaOf got\n%s", data)
+	}
+
+	if _, err := JSONSchemaOf("user"); err == nil {
+		t.Errorf("JSONSchemaOf should failed for string")
+	}
+
+	if _, err := JSONSchemaOf(nil); err == nil {
+		t.Errorf("JSONSchemaOf should failed for nil")
+	}
+}
@@ -0,0 +1,398 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Checkers of JSON Schema formats, unknown formats are only annotations
+var jsonFormatCheckers = map[string]ValidaterFunc{
+	"email":    emailChecker,
+	"uri":      urlChecker,
+	"ipv4":     ipv4Checker,
+	"ipv6":     ipv6Checker,
+	"hostname": hostnameChecker,
+	"uuid":     uuidChecker,
+	"date-time": func(v interface{}) error {
+		str, err := stringValue(v)
+		if err != nil {
+			return err
+		}
+
+		if _, err := time.Parse(time.RFC3339, str); err != nil {
+			return fmt.Errorf("date-time should be RFC 3339, such as 2006-01-02T15:04:05Z")
+		}
+
+		return nil
+	},
+}
+
+// ParseJSONSchema parse JSON Schema document, only keywords of JSONSchema are kept.
+// "$ref" loops which never reach a nested value, such as "A" ref to "A", are
+// errors.
+func ParseJSONSchema(data []byte) (*JSONSchema, error) {
+	s := &JSONSchema{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("bad json schema: %s", err)
+	}
+
+	if err := checkRefLoop(s); err != nil {
+		return nil, fmt.Errorf("bad json schema: %s", err)
+	}
+
+	return s, nil
+}
+
+// Find "$ref" loop on same value, $ref, not and anyOf are checked on the
+// value itself, others on nested values which are finite
+func checkRefLoop(root *JSONSchema) error {
+	done := make(map[*JSONSchema]bool)
+	inChain := make(map[*JSONSchema]bool)
+	queue := []*JSONSchema{root}
+
+	var walk func(s *JSONSchema, ref string) error
+	walk = func(s *JSONSchema, ref string) error {
+		if inChain[s] {
+			return fmt.Errorf("$ref [%s] loops without nested value", ref)
+		}
+		if done[s] {
+			return nil
+		}
+
+		inChain[s] = true
+		defer delete(inChain, s)
+
+		if s.Ref != "" {
+			if target, err := resolveRef(s.Ref, root); err == nil {
+				if err := walk(target, s.Ref); err != nil {
+					return err
+				}
+			}
+		}
+
+		same := append([]*JSONSchema(nil), s.AnyOf...)
+		if s.Not != nil {
+			same = append(same, s.Not)
+		}
+		for _, sub := range same {
+			if err := walk(sub, ref); err != nil {
+				return err
+			}
+		}
+		done[s] = true
+
+		// Nested values start new chains
+		queue = append(queue, s.Items, s.AdditionalProperties)
+		for _, m := range []map[string]*JSONSchema{s.Properties, s.Defs} {
+			names := make([]string, 0, len(m))
+			for name := range m {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				queue = append(queue, m[name])
+			}
+		}
+
+		return nil
+	}
+
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		if s == nil {
+			continue
+		}
+
+		if err := walk(s, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadJSONSchema read JSON Schema from file system, such as embed.FS or os.DirFS(".")
+//
+//	//go:embed schemas
+//	var schemas embed.FS
+//
+//	schema, err := validation.LoadJSONSchema(schemas, "schemas/user.json")
+func LoadJSONSchema(fsys fs.FS, name string) (*JSONSchema, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseJSONSchema(data)
+}
+
+// ValidateJSONSchema check value by JSON Schema, value can be decoded JSON,
+// json.RawMessage or Go value which is checked as its JSON encoding.
+// Errors are reported by path, "address.city" and "tags[1]", "Object" for root.
+func (mv *Validation) ValidateJSONSchema(value interface{}, schema *JSONSchema) bool {
+	if schema == nil {
+		return !mv.HasError()
+	}
+
+	doc, err := jsonDocument(value)
+	if err != nil {
+		mv.addError("Object", value, err)
+		return false
+	}
+
+	mv.checkSchema("", doc, schema, schema)
+
+	return !mv.HasError()
+}
+
+// Return value as decoded JSON, numbers are json.Number
+func jsonDocument(value interface{}) (interface{}, error) {
+	data, ok := value.(json.RawMessage)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return nil, err
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// Return "$ref" target, "#" or "#/$defs/Name"
+func resolveRef(ref string, root *JSONSchema) (*JSONSchema, error) {
+	if ref == "#" {
+		return root, nil
+	}
+
+	if name := strings.TrimPrefix(ref, "#/$defs/"); name != ref && root.Defs[name] != nil {
+		return root.Defs[name], nil
+	}
+
+	return nil, fmt.Errorf("can't resolve $ref [%s]", ref)
+}
+
+func schemaPath(path string) string {
+	if path == "" {
+		return "Object"
+	}
+
+	return path
+}
+
+// Check decoded JSON value by schema, root for "$ref"
+func (mv *Validation) checkSchema(path string, v interface{}, s, root *JSONSchema) {
+	name := schemaPath(path)
+
+	// Schema built by hand can loop, stop it like nested structs
+	if maxDepth := mv.depthLimit(); mv.depth >= maxDepth {
+		mv.addError(name, v, &ErrMaxDepth{Depth: maxDepth, Type: reflect.TypeOf(v)})
+		return
+	}
+	mv.depth++
+	defer func() { mv.depth-- }()
+
+	if s.Ref != "" {
+		target, err := resolveRef(s.Ref, root)
+		if err != nil {
+			mv.addError(name, v, err)
+			return
+		}
+		mv.checkSchema(path, v, target, root)
+	}
+
+	if len(s.Type) > 0 && !isJSONType(v, s.Type) {
+		mv.addError(name, v, NewErrWrongType(strings.Join(s.Type, " or "), v))
+		return
+	}
+
+	if len(s.Enum) > 0 && !inEnum(v, s.Enum) {
+		values := make([]string, len(s.Enum))
+		for i, e := range s.Enum {
+			values[i] = fmt.Sprint(e)
+		}
+		mv.addError(name, v, &ErrNotOneOf{Values: values})
+	}
+
+	if s.Not != nil {
+		n := len(mv.Errors)
+		mv.checkSchema(path, v, s.Not, root)
+		matched := len(mv.Errors) == n
+		mv.Errors = mv.Errors[:n]
+
+		if matched && s.isFalse() {
+			mv.addError(name, v, ErrSchemaFalse)
+		} else if matched {
+			mv.addError(name, v, ErrSchemaNot)
+		}
+	}
+
+	if len(s.AnyOf) > 0 {
+		mv.checkAnyOf(path, v, s.AnyOf, root)
+	}
+
+	switch val := v.(type) {
+	case string:
+		mv.checkSchemaString(name, val, s)
+
+	case json.Number:
+		f, _ := val.Float64()
+		if s.Minimum != nil && f < *s.Minimum {
+			mv.addError(name, v, &ErrNumberRange{Keyword: "minimum", Limit: *s.Minimum})
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			mv.addError(name, v, &ErrNumberRange{Keyword: "maximum", Limit: *s.Maximum})
+		}
+
+	case []interface{}:
+		if s.Items != nil {
+			for i, e := range val {
+				mv.checkSchema(fmt.Sprintf("%s[%d]", path, i), e, s.Items, root)
+			}
+		}
+
+	case map[string]interface{}:
+		for _, key := range s.Required {
+			if _, ok := val[key]; !ok {
+				mv.addError(joinPath(path, key), nil, ErrRequired)
+			}
+		}
+
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if ps, ok := s.Properties[key]; ok {
+				mv.checkSchema(joinPath(path, key), val[key], ps, root)
+			} else if s.AdditionalProperties != nil {
+				mv.checkSchema(joinPath(path, key), val[key], s.AdditionalProperties, root)
+			}
+		}
+	}
+}
+
+// Check value match any schema, report errors of first schema if none matched
+func (mv *Validation) checkAnyOf(path string, v interface{}, schemas []*JSONSchema, root *JSONSchema) {
+	n := len(mv.Errors)
+	var first []*Error
+
+	for i, s := range schemas {
+		mv.checkSchema(path, v, s, root)
+		if len(mv.Errors) == n {
+			return
+		}
+
+		if i == 0 {
+			first = append(first, mv.Errors[n:]...)
+		}
+		mv.Errors = mv.Errors[:n]
+	}
+
+	mv.Errors = append(mv.Errors, first...)
+}
+
+func (mv *Validation) checkSchemaString(name, str string, s *JSONSchema) {
+	n := len([]rune(str))
+	if s.MinLength != nil && n < *s.MinLength {
+		mv.addError(name, str, &ErrLength{Rule: MinLenKey, Limit: *s.MinLength})
+	}
+
+	if s.MaxLength != nil && n > *s.MaxLength {
+		mv.addError(name, str, &ErrLength{Rule: MaxLenKey, Limit: *s.MaxLength})
+	}
+
+	if s.Pattern != "" {
+		rx, err := mv.pattern(s.Pattern)
+		if err != nil {
+			mv.addError(name, str, err)
+		} else if !rx.MatchString(str) {
+			mv.addError(name, str, &ErrPatternMismatch{Pattern: s.Pattern})
+		}
+	}
+
+	if check, ok := jsonFormatCheckers[s.Format]; ok {
+		if err := check(str); err != nil {
+			mv.addError(name, str, err)
+		}
+	}
+}
+
+// Return true if decoded JSON value is of any type
+func isJSONType(v interface{}, types JSONType) bool {
+	for _, typ := range types {
+		if isJSONTypeName(v, typ) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return true if decoded JSON value is of type, 1.0 is integer
+func isJSONTypeName(v interface{}, typ string) bool {
+	switch val := v.(type) {
+	case nil:
+		return typ == "null"
+	case bool:
+		return typ == "boolean"
+	case string:
+		return typ == "string"
+	case json.Number:
+		if typ == "number" {
+			return true
+		}
+		f, err := val.Float64()
+		return typ == "integer" && err == nil && f == math.Trunc(f)
+	case []interface{}:
+		return typ == "array"
+	case map[string]interface{}:
+		return typ == "object"
+	}
+
+	return false
+}
+
+// Return true if v equal to any value of enum, numbers are compared by value
+func inEnum(v interface{}, enum []interface{}) bool {
+	n, isNumber := v.(json.Number)
+
+	for _, e := range enum {
+		if isNumber {
+			ev := reflect.ValueOf(e)
+			if kindClass(ev) == "" || kindClass(ev) == "string" {
+				continue
+			}
+
+			f, _ := n.Float64()
+			if f == floatValue(ev) {
+				return true
+			}
+			continue
+		}
+
+		if reflect.DeepEqual(v, e) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,208 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidateJSONSchema(t *testing.T) {
+	schema, err := LoadJSONSchema(os.DirFS("testdata"), "order.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		doc    string
+		fields []string
+	}{
+		{`{"id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "email": "dave@do1618.com", "homepage": "https://do1618.com",
+			"status": "paid", "quantity": 2.0, "note": "fast", "created": "2024-01-02T03:04:05Z",
+			"items": [{"sku": "x1"}], "labels": {"a": "abc"}}`, nil},
+		{`{}`, []string{"id", "email", "items"}},
+		{`{"id": "bad", "email": "dave", "homepage": "http://", "status": "lost", "quantity": 0.5,
+			"note": "x", "created": "2024-01-02", "items": [{}, {"sku": "x"}], "labels": {"a": "ABC"}}`,
+			[]string{"created", "email", "homepage", "id", "items[0].sku", "items[1].sku", "labels.a", "note", "quantity", "status"}},
+		{`{"id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "email": "dave@do1618.com", "items": [], "quantity": 101}`,
+			[]string{"quantity"}},
+		{`[]`, []string{"Object"}},
+	}
+
+	validor := NewValidation()
+	for _, test := range tests {
+		validor.Reset()
+		ok := validor.ValidateJSONSchema(json.RawMessage(test.doc), schema)
+		if ok != (len(test.fields) == 0) {
+			t.Errorf("ValidateJSONSchema(%s) got %v: %s", test.doc, ok, validor.ErrMsg())
+			continue
+		}
+
+		if len(validor.Errs()) != len(test.fields) {
+			t.Errorf("ValidateJSONSchema(%s) should got errors on %v, but got %s", test.doc, test.fields, validor.ErrMsg())
+			continue
+		}
+
+		for i, name := range test.fields {
+			if validor.Errs()[i].FieldName != name {
+				t.Errorf("ValidateJSONSchema(%s) error %d should be on %s, but got %s", test.doc, i, name, validor.Errs()[i].FieldName)
+			}
+		}
+	}
+
+	validor.Reset()
+	validor.ValidateJSONSchema(json.RawMessage(`{"id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "email": "a@b.c", "items": [], "quantity": 0}`), schema)
+	var rangeErr *ErrNumberRange
+	if len(validor.Errs()) != 1 || !errors.As(validor.Errs()[0].Err, &rangeErr) || rangeErr.Keyword != "minimum" {
+		t.Errorf("should got minimum error, but got %s", validor.ErrMsg())
+	}
+}
+
+func TestValidateJSONSchemaGoValue(t *testing.T) {
+	schema, err := JSONSchemaOf(SchemaUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Round trip by JSON, generated schema is loaded as other documents
+	data, _ := json.Marshal(schema)
+	if schema, err = ParseJSONSchema(data); err != nil {
+		t.Fatal(err)
+	}
+
+	user := SchemaUser{
+		SchemaBase: SchemaBase{ID: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		Name:       "dave",
+		Email:      "dave@do1618.com",
+		Homepage:   "https://do1618.com",
+		Role:       "admin",
+		Level:      2,
+		Address:    &SchemaAddress{City: "Beijing", Zip: "100000"},
+	}
+
+	validor := NewValidation()
+	if !validor.ValidateJSONSchema(user, schema) {
+		t.Errorf("ValidateJSONSchema should succeed, but got %s", validor.ErrMsg())
+	}
+
+	user.Role = "root"
+	user.Billing = &SchemaAddress{City: "Beijing", Zip: "1"}
+	user.Tags = []string{"admin1"}
+	user.Friends = []*SchemaUser{{SchemaBase: user.SchemaBase, Name: "x", Homepage: "https://do1618.com", Role: "user", Level: 9}}
+
+	validor.Reset()
+	if validor.ValidateJSONSchema(user, schema) {
+		t.Fatalf("ValidateJSONSchema should failed")
+	}
+
+	want := []string{"billing.zip", "friends[0].address", "friends[0].level", "friends[0].name", "role", "tags[0]"}
+	if len(validor.Errs()) != len(want) {
+		t.Fatalf("should got errors on %v, but got %s", want, validor.ErrMsg())
+	}
+
+	for i, name := range want {
+		if validor.Errs()[i].FieldName != name {
+			t.Errorf("error %d should be on %s, but got %s", i, name, validor.Errs()[i].FieldName)
+		}
+	}
+
+	if _, err := ParseJSONSchema([]byte(`{"type": 1}`)); err == nil {
+		t.Errorf("ParseJSONSchema should failed for bad type")
+	}
+}
+
+func TestJSONSchemaBool(t *testing.T) {
+	schema, err := ParseJSONSchema([]byte(`{
+		"type": "object",
+		"properties": {"name": true, "meta": {"type": "object", "additionalProperties": false}, "old": false},
+		"items": false
+	}`))
+	if err != nil {
+		t.Fatalf("ParseJSONSchema should accept boolean schemas, but got %s", err)
+	}
+
+	tests := []struct {
+		doc    string
+		fields []string
+	}{
+		{`{"name": 1, "meta": {}}`, nil},
+		{`{"name": "x", "meta": {"a": 1}, "old": 1}`, []string{"meta.a", "old"}},
+	}
+
+	validor := NewValidation()
+	for _, test := range tests {
+		validor.Reset()
+		validor.ValidateJSONSchema(json.RawMessage(test.doc), schema)
+
+		if len(validor.Errs()) != len(test.fields) {
+			t.Errorf("ValidateJSONSchema(%s) should got errors on %v, but got %s", test.doc, test.fields, validor.ErrMsg())
+			continue
+		}
+
+		for i, name := range test.fields {
+			if e := validor.Errs()[i]; e.FieldName != name || e.Err != ErrSchemaFalse {
+				t.Errorf("error %d should be ErrSchemaFalse on %s, but got %s", i, name, e)
+			}
+		}
+	}
+
+	if _, err := ParseJSONSchema([]byte(`false`)); err != nil {
+		t.Errorf("ParseJSONSchema should accept schema false, but got %s", err)
+	}
+}
+
+func TestJSONSchemaPatternNotNamed(t *testing.T) {
+	if err := RegisterPattern("digits", `^[0-9]+$`); err != nil {
+		t.Fatal(err)
+	}
+
+	// "digits" is a literal pattern, not the registered one
+	schema := &JSONSchema{Type: JSONType{"string"}, Pattern: "digits"}
+
+	validor := NewValidation()
+	if !validor.ValidateJSONSchema(json.RawMessage(`"only digits"`), schema) {
+		t.Errorf("literal pattern should match, but got %s", validor.ErrMsg())
+	}
+
+	validor.Reset()
+	if validor.ValidateJSONSchema(json.RawMessage(`"123"`), schema) {
+		t.Errorf("literal pattern should not match 123")
+	}
+}
+
+func TestJSONSchemaRefLoop(t *testing.T) {
+	loops := []string{
+		`{"$defs": {"A": {"$ref": "#/$defs/A"}}, "$ref": "#/$defs/A"}`,
+		`{"$defs": {"A": {"$ref": "#/$defs/B"}, "B": {"anyOf": [{"$ref": "#/$defs/A"}]}}, "$ref": "#/$defs/A"}`,
+		`{"not": {"$ref": "#"}}`,
+	}
+
+	for _, data := range loops {
+		if _, err := ParseJSONSchema([]byte(data)); err == nil || !strings.Contains(err.Error(), "loops") {
+			t.Errorf("ParseJSONSchema(%s) should fail for $ref loop, but got %v", data, err)
+		}
+	}
+
+	// Recursion on nested values is fine
+	schema, err := ParseJSONSchema([]byte(`{"$ref": "#/$defs/Node", "$defs": {"Node": {"type": "object",
+		"properties": {"name": {"type": "string"}, "next": {"$ref": "#/$defs/Node"}, "root": {"$ref": "#"}}}}}`))
+	if err != nil {
+		t.Fatalf("ParseJSONSchema should accept nested recursion, but got %s", err)
+	}
+
+	validor := NewValidation()
+	if !validor.ValidateJSONSchema(json.RawMessage(`{"name": "a", "next": {"name": "b", "root": {"name": "c"}}}`), schema) {
+		t.Errorf("ValidateJSONSchema should succeed, but got %s", validor.ErrMsg())
+	}
+
+	// Loop built by hand is stopped by max depth
+	loop := &JSONSchema{Ref: "#/$defs/A", Defs: map[string]*JSONSchema{"A": {Ref: "#/$defs/A"}}}
+
+	validor.Reset()
+	validor.ValidateJSONSchema(json.RawMessage(`{}`), loop)
+	var depthErr *ErrMaxDepth
+	if len(validor.Errs()) != 1 || !errors.As(validor.Errs()[0].Err, &depthErr) {
+		t.Errorf("should got ErrMaxDepth, but got %s", validor.ErrMsg())
+	}
+}
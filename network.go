@@ -0,0 +1,255 @@
+package validation
+
+import (
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Network checker names
+const (
+	IPKey        = "ip"
+	IPv4Key      = "ipv4"
+	IPv6Key      = "ipv6"
+	CIDRKey      = "cidr"
+	IPInKey      = "ip_in" // ip_in=10.0.0.0/8,192.168.0.0/16
+	PrivateIPKey = "private_ip"
+	MACKey       = "mac"
+	HostPortKey  = "hostport"
+	PortKey      = "port"
+	HostnameKey  = "hostname_rfc1123"
+	FQDNKey      = "fqdn"
+
+	maxHostnameLen = 253
+	maxLabelLen    = 63
+)
+
+func stringValue(v interface{}) (string, error) {
+	str, ok := v.(string)
+	if !ok {
+		return "", NewErrWrongType("string", v)
+	}
+
+	return str, nil
+}
+
+func parseAddr(v interface{}, bad error) (netip.Addr, error) {
+	str, err := stringValue(v)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	addr, err := netip.ParseAddr(str)
+	if err != nil {
+		return netip.Addr{}, bad
+	}
+
+	return addr, nil
+}
+
+func ipChecker(v interface{}) error {
+	_, err := parseAddr(v, ErrBadIPFormat)
+	return err
+}
+
+func ipv4Checker(v interface{}) error {
+	addr, err := parseAddr(v, ErrBadIPv4Format)
+	if err != nil {
+		return err
+	}
+
+	if !addr.Is4() {
+		return ErrBadIPv4Format
+	}
+
+	return nil
+}
+
+func ipv6Checker(v interface{}) error {
+	addr, err := parseAddr(v, ErrBadIPv6Format)
+	if err != nil {
+		return err
+	}
+
+	if !addr.Is6() {
+		return ErrBadIPv6Format
+	}
+
+	return nil
+}
+
+func privateIPChecker(v interface{}) error {
+	addr, err := parseAddr(v, ErrBadIPFormat)
+	if err != nil {
+		return err
+	}
+
+	if !addr.Unmap().IsPrivate() {
+		return ErrNotPrivateIP
+	}
+
+	return nil
+}
+
+func cidrChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	if _, err := netip.ParsePrefix(str); err != nil {
+		return ErrBadCIDRFormat
+	}
+
+	return nil
+}
+
+func macChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	if _, err := net.ParseMAC(str); err != nil {
+		return ErrBadMACFormat
+	}
+
+	return nil
+}
+
+// Port accept string and all integer types, named ints too, range 1-65535
+func portChecker(v interface{}) error {
+	var valid bool
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		valid = rv.Int() >= 1 && rv.Int() <= 65535
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		valid = rv.Uint() >= 1 && rv.Uint() <= 65535
+	default:
+		str, ok := v.(string)
+		if !ok {
+			return NewErrWrongType("string or int", v)
+		}
+
+		n, err := strconv.ParseUint(str, 10, 16)
+		valid = err == nil && n >= 1
+	}
+
+	if !valid {
+		return ErrBadPortFormat
+	}
+
+	return nil
+}
+
+func hostPortChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	host, port, err := net.SplitHostPort(str)
+	if err != nil || host == "" {
+		return ErrBadHostPortFormat
+	}
+
+	if portChecker(port) != nil {
+		return ErrBadHostPortFormat
+	}
+
+	if _, err := netip.ParseAddr(host); err != nil && !isHostname(host) {
+		return ErrBadHostPortFormat
+	}
+
+	return nil
+}
+
+func hostnameChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	if !isHostname(str) {
+		return ErrBadHostnameFormat
+	}
+
+	return nil
+}
+
+// FQDN need at least two labels and not numeric tld, trailing dot is allowed
+func fqdnChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	str = strings.TrimSuffix(str, ".")
+	i := strings.LastIndex(str, ".")
+	if i < 0 || !isHostname(str) {
+		return ErrBadFQDNFormat
+	}
+
+	if _, err := strconv.Atoi(str[i+1:]); err == nil {
+		return ErrBadFQDNFormat
+	}
+
+	return nil
+}
+
+// Check hostname by RFC 1123, labels can start with digit
+func isHostname(host string) bool {
+	if host == "" || len(host) > maxHostnameLen {
+		return false
+	}
+
+	for _, label := range strings.Split(host, ".") {
+		if len(label) == 0 || len(label) > maxLabelLen {
+			return false
+		}
+
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func ipInBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	var prefixes []netip.Prefix
+	for _, p := range strings.Split(param, ",") {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(p))
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return func(v interface{}) error {
+		addr, err := parseAddr(v, ErrBadIPFormat)
+		if err != nil {
+			return err
+		}
+
+		addr = addr.Unmap()
+		for _, prefix := range prefixes {
+			if prefix.Contains(addr) {
+				return nil
+			}
+		}
+
+		return &ErrIPNotInRange{Ranges: param}
+	}, nil
+}
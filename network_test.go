@@ -0,0 +1,76 @@
+package validation
+
+import "testing"
+
+type testPort uint16
+
+func TestNetworkCheckers(t *testing.T) {
+	tests := []struct {
+		Checker ValidaterFunc
+		Value   interface{}
+		Expect  bool
+	}{
+		{ipChecker, "10.0.0.1", true},
+		{ipChecker, "fe80::1%eth0", true},
+		{ipChecker, "10.0.0.256", false},
+		{ipChecker, 10, false},
+		{ipv4Checker, "192.168.1.1", true},
+		{ipv4Checker, "::1", false},
+		{ipv6Checker, "2001:db8::1", true},
+		{ipv6Checker, "1.2.3.4", false},
+		{cidrChecker, "10.0.0.0/8", true},
+		{cidrChecker, "10.0.0.0/33", false},
+		{privateIPChecker, "172.16.3.4", true},
+		{privateIPChecker, "8.8.8.8", false},
+		{macChecker, "00:1a:2b:3c:4d:5e", true},
+		{macChecker, "00:1a:2b:3c:4d", false},
+		{portChecker, "8080", true},
+		{portChecker, 443, true},
+		{portChecker, "0", false},
+		{portChecker, 70000, false},
+		{portChecker, int8(80), true},
+		{portChecker, int16(-1), false},
+		{portChecker, uint8(0), false},
+		{portChecker, uint32(8080), true},
+		{portChecker, uint64(1 << 40), false},
+		{portChecker, testPort(443), true},
+		{portChecker, 80.0, false},
+		{hostPortChecker, "localhost:8080", true},
+		{hostPortChecker, "[::1]:53", true},
+		{hostPortChecker, "localhost", false},
+		{hostPortChecker, "bad_host:80", false},
+		{hostnameChecker, "3com.example", true},
+		{hostnameChecker, "-bad.example", false},
+		{hostnameChecker, "a..b", false},
+		{fqdnChecker, "www.do1618.com.", true},
+		{fqdnChecker, "localhost", false},
+		{fqdnChecker, "10.0.0.1", false},
+	}
+
+	for i, test := range tests {
+		err := test.Checker(test.Value)
+		if (err == nil) != test.Expect {
+			t.Errorf("case %d [%v] should [%t], got err %v", i, test.Value, test.Expect, err)
+		}
+	}
+}
+
+func TestIPIn(t *testing.T) {
+	obj := struct {
+		Addrs []string `valid:"ip_in=10.0.0.0/8, 192.168.0.0/16"`
+		Bad   string   `valid:"ip_in=10.0.0.0"`
+	}{
+		Addrs: []string{"10.1.2.3", "192.168.3.4", "172.16.0.1", "::ffff:10.0.0.1"},
+	}
+
+	validor := NewValidation()
+	validor.Validate(obj)
+
+	if len(validor.Errs()) != 2 {
+		t.Fatalf("should got 2 errors, but got %s", validor.ErrMsg())
+	}
+
+	if _, ok := validor.Errs()[0].Err.(*ErrIPNotInRange); !ok {
+		t.Errorf("should got ErrIPNotInRange, but got %s", validor.Errs()[0].Err)
+	}
+}
@@ -0,0 +1,145 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Prefix of "$ref" to struct in OpenAPI components
+const openAPISchemas = "#/components/schemas/"
+
+// OpenAPI build OpenAPI 3.1 components from tagged structs. Schemas are JSON
+// Schema same as JSONSchemaOf, nested structs are shared by all of them.
+//
+//	api := validation.NewOpenAPI()
+//	api.AddSchema(CreateUserRequest{})
+//	api.AddParameters(ListUsersQuery{}, "query")
+//	data, err := api.Components().YAML()
+type OpenAPI struct {
+	g      *schemaGen
+	params map[string]*OpenAPIParameter
+}
+
+// OpenAPIComponents "components" object of OpenAPI document
+type OpenAPIComponents struct {
+	Schemas    map[string]*JSONSchema       `json:"schemas,omitempty"`
+	Parameters map[string]*OpenAPIParameter `json:"parameters,omitempty"`
+}
+
+// OpenAPIParameter parameter object of OpenAPI document
+type OpenAPIParameter struct {
+	Name     string      `json:"name"`
+	In       string      `json:"in"`
+	Required bool        `json:"required,omitempty"`
+	Schema   *JSONSchema `json:"schema"`
+}
+
+// Locations of parameter, also the tag of parameter name, `query:"page"`
+var openAPIParamIn = map[string]bool{
+	"query":  true,
+	"header": true,
+	"path":   true,
+	"cookie": true,
+}
+
+// NewOpenAPI return empty OpenAPI components builder
+func NewOpenAPI() *OpenAPI {
+	return &OpenAPI{
+		g:      newSchemaGen(openAPISchemas),
+		params: make(map[string]*OpenAPIParameter),
+	}
+}
+
+// AddSchema add schema of struct to components, return its "$ref"
+func (api *OpenAPI) AddSchema(obj interface{}) (string, error) {
+	t, err := structType(obj)
+	if err != nil {
+		return "", err
+	}
+
+	if t.Name() == "" {
+		return "", fmt.Errorf("schema need named struct, but got %s", t)
+	}
+
+	return api.g.ref(t).Ref, nil
+}
+
+// AddParameters add fields with tag of in as parameters, in is "query",
+// "header", "path" or "cookie". Parameters are named "Type.name" in
+// components, path parameters are always required.
+//
+//	type ListUsersQuery struct {
+//		Page int    `query:"page" valid:"required"`
+//		Sort string `query:"sort" valid:"oneof=name,age"`
+//	}
+func (api *OpenAPI) AddParameters(obj interface{}, in string) ([]*OpenAPIParameter, error) {
+	if !openAPIParamIn[in] {
+		return nil, fmt.Errorf("bad parameter location [%s]", in)
+	}
+
+	t, err := structType(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var params []*OpenAPIParameter
+	for _, tf := range reflect.VisibleFields(t) {
+		name := tf.Tag.Get(in)
+		if len(tf.PkgPath) > 0 || name == "" || name == "-" {
+			continue
+		}
+
+		tag, _, ok := promotedTag(t, tf)
+		if !ok {
+			continue
+		}
+
+		rules, required := splitRequired(parseTag(tag))
+
+		p := &OpenAPIParameter{
+			Name:     name,
+			In:       in,
+			Required: required || in == "path",
+			Schema:   api.g.value(tf.Type, rules),
+		}
+		params = append(params, p)
+		api.params[t.Name()+"."+name] = p
+	}
+
+	return params, nil
+}
+
+// Components return components with all added schemas and parameters
+func (api *OpenAPI) Components() *OpenAPIComponents {
+	c := &OpenAPIComponents{}
+	if len(api.g.defs) > 0 {
+		c.Schemas = api.g.defs
+	}
+
+	if len(api.params) > 0 {
+		c.Parameters = api.params
+	}
+
+	return c
+}
+
+// Document with components only, can be merged to the spec
+type openAPIDocument struct {
+	Components *OpenAPIComponents `json:"components"`
+}
+
+// JSON return {"components": ...} document
+func (c *OpenAPIComponents) JSON() ([]byte, error) {
+	return json.MarshalIndent(&openAPIDocument{Components: c}, "", "  ")
+}
+
+// YAML return "components:" document, keys in same order as JSON
+func (c *OpenAPIComponents) YAML() ([]byte, error) {
+	data, err := json.Marshal(&openAPIDocument{Components: c})
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonToYAML(data)
+}
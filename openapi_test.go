@@ -0,0 +1,90 @@
+package validation
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+type ListUsersQuery struct {
+	Page  int    `query:"page" valid:"required"`
+	Sort  string `query:"sort" valid:"oneof=name,age"`
+	Skip  string
+	Token string `header:"X-Token" valid:"min_len=8"`
+}
+
+type UserPath struct {
+	ID string `path:"id" valid:"uuid"`
+}
+
+func TestOpenAPI(t *testing.T) {
+	api := NewOpenAPI()
+
+	ref, err := api.AddSchema(&SchemaUser{})
+	if err != nil || ref != "#/components/schemas/SchemaUser" {
+		t.Fatalf("AddSchema got %s, %v", ref, err)
+	}
+
+	params, err := api.AddParameters(ListUsersQuery{}, "query")
+	if err != nil || len(params) != 2 || params[0].Name != "page" || !params[0].Required {
+		t.Fatalf("AddParameters got %v, %v", params, err)
+	}
+
+	if _, err := api.AddParameters(ListUsersQuery{}, "header"); err != nil {
+		t.Fatal(err)
+	}
+
+	if params, _ := api.AddParameters(UserPath{}, "path"); len(params) != 1 || !params[0].Required {
+		t.Errorf("path parameter should be required, but got %v", params)
+	}
+
+	if _, err := api.AddParameters(UserPath{}, "body"); err == nil {
+		t.Errorf("AddParameters should failed for body")
+	}
+
+	if _, err := api.AddSchema(struct{ Name string }{}); err == nil {
+		t.Errorf("AddSchema should failed for anonymous struct")
+	}
+
+	data, err := api.Components().YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden, err := os.ReadFile("testdata/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != string(golden) {
+		t.Errorf("YAML got\n%s", data)
+	}
+
+	if data, err := api.Components().JSON(); err != nil || !strings.HasPrefix(string(data), "{\n  \"components\": {") {
+		t.Errorf("JSON got %s, %v", data, err)
+	}
+}
+
+func TestJSONToYAML(t *testing.T) {
+	tests := []struct {
+		json string
+		yaml string
+	}{
+		{`{"a": 1, "b": "x y", "c": true, "d": null}`, "a: 1\nb: \"x y\"\nc: true\nd: null\n"},
+		{`{"list": [1, {"k": "v", "n": "no"}, [], {}]}`, "list:\n  - 1\n  - k: v\n    \"n\": \"no\"\n  - []\n  - {}\n"},
+		{`{"$ref": "#/a", "p": "^[a-z]+$"}`, "\"$ref\": \"#/a\"\np: \"^[a-z]+$\"\n"},
+		{`[[1, 2], {"a": {"b": [3]}}]`, "- - 1\n  - 2\n- a:\n    b:\n      - 3\n"},
+	}
+
+	for _, test := range tests {
+		data, err := jsonToYAML([]byte(test.json))
+		if err != nil {
+			t.Errorf("jsonToYAML(%s) failed: %s", test.json, err)
+			continue
+		}
+
+		if string(data) != test.yaml {
+			t.Errorf("jsonToYAML(%s) should be\n%s\nbut got\n%s", test.json, test.yaml, data)
+		}
+	}
+}
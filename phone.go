@@ -0,0 +1,227 @@
+package validation
+
+import (
+	_ "embed" // phone metadata in data dir
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Phone checker names
+//
+//	e164        "+8613812345678", plus and 1-15 digits without separators
+//	phone       international number of any region in phone metadata
+//	phone=CN    national or international number of region CN
+const (
+	E164Key  = "e164"
+	PhoneKey = "phone"
+
+	maxE164Digits = 15
+)
+
+// PhoneMetadata rule for national significant number of one region,
+// a region can have many, such as mobile and fixed line.
+type PhoneMetadata struct {
+	Region        string   // ISO 3166-1 alpha-2, "CN"
+	CallingCode   string   // "86"
+	TrunkPrefix   string   // dialed before national number, "0", empty if none
+	Lengths       []int    // accepted lengths of national number
+	LeadingDigits []string // accepted prefixes of national number
+}
+
+var (
+	//go:embed data/phone.txt
+	phoneData string
+
+	// PhoneMetadataVersion version of embedded phone metadata
+	PhoneMetadataVersion = dataVersion(phoneData)
+
+	// Phone metadata by region, using rwlock avoid race
+	phoneRegions = struct {
+		sync.RWMutex
+		m map[string][]PhoneMetadata
+	}{m: loadPhoneMetadata()}
+)
+
+func loadPhoneMetadata() map[string][]PhoneMetadata {
+	m := make(map[string][]PhoneMetadata)
+	for _, row := range dataRows(phoneData) {
+		md, err := parsePhoneRow(row)
+		if err != nil {
+			panic(err)
+		}
+		m[md.Region] = append(m[md.Region], md)
+	}
+
+	return m
+}
+
+// Parse "CN 86 0 10,11 2,3", lengths can be range "6-11", "-" for no trunk prefix
+func parsePhoneRow(row []string) (PhoneMetadata, error) {
+	if len(row) != 5 {
+		return PhoneMetadata{}, fmt.Errorf("bad phone metadata %v", row)
+	}
+
+	md := PhoneMetadata{Region: row[0], CallingCode: row[1], LeadingDigits: strings.Split(row[4], ",")}
+	if row[2] != "-" {
+		md.TrunkPrefix = row[2]
+	}
+
+	for _, l := range strings.Split(row[3], ",") {
+		from, to := l, l
+		if i := strings.IndexByte(l, '-'); i > 0 {
+			from, to = l[:i], l[i+1:]
+		}
+
+		min, err1 := strconv.Atoi(from)
+		max, err2 := strconv.Atoi(to)
+		if err1 != nil || err2 != nil || min > max {
+			return PhoneMetadata{}, fmt.Errorf("bad phone metadata length [%s]", l)
+		}
+
+		for n := min; n <= max; n++ {
+			md.Lengths = append(md.Lengths, n)
+		}
+	}
+
+	return md, nil
+}
+
+// AddPhoneMetadata add rule for region, used with embedded metadata
+func AddPhoneMetadata(md PhoneMetadata) error {
+	if md.Region == "" || !isNumeric(md.CallingCode) || len(md.Lengths) == 0 || len(md.LeadingDigits) == 0 {
+		return fmt.Errorf("phone metadata need region, calling code, lengths and leading digits")
+	}
+
+	phoneRegions.Lock()
+	phoneRegions.m[md.Region] = append(phoneRegions.m[md.Region], md)
+	phoneRegions.Unlock()
+
+	return nil
+}
+
+func findPhoneMetadata(region string) []PhoneMetadata {
+	phoneRegions.RLock()
+	mds := phoneRegions.m[region]
+	phoneRegions.RUnlock()
+
+	return mds
+}
+
+// Remove separators used for readability
+func normalizePhone(str string) string {
+	return strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "").Replace(str)
+}
+
+// Check national significant number by metadata
+func (md *PhoneMetadata) match(national string) bool {
+	lengthOK := false
+	for _, l := range md.Lengths {
+		if len(national) == l {
+			lengthOK = true
+			break
+		}
+	}
+
+	if !lengthOK {
+		return false
+	}
+
+	for _, prefix := range md.LeadingDigits {
+		if strings.HasPrefix(national, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func e164Checker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	if !isE164(str) {
+		return &ErrBadPhone{Reason: "should be + and 1-15 digits, first digit not 0"}
+	}
+
+	return nil
+}
+
+func isE164(str string) bool {
+	if len(str) < 2 || str[0] != '+' || str[1] == '0' {
+		return false
+	}
+
+	digits := str[1:]
+	return len(digits) <= maxE164Digits && isNumeric(digits)
+}
+
+// Check international number against all regions
+func checkPhone(str string) error {
+	number := normalizePhone(str)
+	if !isE164(number) {
+		return &ErrBadPhone{Reason: "should be international number, such as +8613812345678"}
+	}
+
+	phoneRegions.RLock()
+	defer phoneRegions.RUnlock()
+
+	for _, mds := range phoneRegions.m {
+		for i := range mds {
+			national := strings.TrimPrefix(number[1:], mds[i].CallingCode)
+			if len(national) < len(number)-1 && mds[i].match(national) {
+				return nil
+			}
+		}
+	}
+
+	return &ErrBadPhone{Reason: "unknown calling code or bad national number"}
+}
+
+// Check national or international number of region
+func checkRegionPhone(region, str string) error {
+	mds := findPhoneMetadata(region)
+	number := normalizePhone(str)
+
+	for i := range mds {
+		md := &mds[i]
+		national := number
+
+		if strings.HasPrefix(number, "+") {
+			if !strings.HasPrefix(number[1:], md.CallingCode) {
+				continue
+			}
+			national = number[1+len(md.CallingCode):]
+		} else if md.TrunkPrefix != "" {
+			national = strings.TrimPrefix(number, md.TrunkPrefix)
+		}
+
+		if isNumeric(national) && md.match(national) {
+			return nil
+		}
+	}
+
+	return &ErrBadPhone{Region: region, Reason: "bad length or leading digits"}
+}
+
+func phoneBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	if param != "" && len(findPhoneMetadata(param)) == 0 {
+		return nil, fmt.Errorf("no phone metadata for region [%s]", param)
+	}
+
+	return func(v interface{}) error {
+		str, err := stringValue(v)
+		if err != nil {
+			return err
+		}
+
+		if param == "" {
+			return checkPhone(str)
+		}
+
+		return checkRegionPhone(param, str)
+	}, nil
+}
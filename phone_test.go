@@ -0,0 +1,75 @@
+package validation
+
+import "testing"
+
+func TestE164(t *testing.T) {
+	tests := []struct {
+		Value  string
+		Expect bool
+	}{
+		{"+8613812345678", true},
+		{"+14155552671", true},
+		{"8613812345678", false},
+		{"+0613812345678", false},
+		{"+86 138 1234 5678", false},
+		{"+1234567890123456", false},
+	}
+
+	for _, test := range tests {
+		err := e164Checker(test.Value)
+		if (err == nil) != test.Expect {
+			t.Errorf("[%s] should [%t], got err %v", test.Value, test.Expect, err)
+		}
+	}
+}
+
+func TestPhone(t *testing.T) {
+	type Signup struct {
+		CN    []string `valid:"phone=CN"`
+		GB    string   `valid:"phone=GB"`
+		Any   string   `valid:"phone"`
+		Mars  string   `valid:"phone=MARS"`
+		Other string   `valid:"phone=XA"`
+	}
+
+	err := AddPhoneMetadata(PhoneMetadata{Region: "XA", CallingCode: "999", Lengths: []int{4}, LeadingDigits: []string{"1"}})
+	if err != nil {
+		t.Fatalf("AddPhoneMetadata should succeed, got %s", err)
+	}
+	t.Cleanup(func() {
+		phoneRegions.Lock()
+		delete(phoneRegions.m, "XA")
+		phoneRegions.Unlock()
+	})
+
+	err = AddPhoneMetadata(PhoneMetadata{Region: "XB", CallingCode: "998", Lengths: []int{4}})
+	if err == nil {
+		t.Errorf("AddPhoneMetadata should failed without leading digits")
+	}
+	if len(findPhoneMetadata("XB")) != 0 {
+		t.Errorf("bad metadata should not be added")
+	}
+
+	obj := Signup{
+		CN:    []string{"13812345678", "+86 138-1234-5678", "010 12345678", "12812345678", "+85213812345678"},
+		GB:    "07911 123456",
+		Any:   "+14155552671",
+		Other: "+9991234",
+	}
+
+	validor := NewValidation()
+	validor.Validate(obj)
+
+	// 12812345678, +852..., MARS region
+	if len(validor.Errs()) != 3 {
+		t.Errorf("should got 3 errors, but got %s", validor.ErrMsg())
+	}
+
+	if err := checkPhone("+99912345"); err == nil {
+		t.Errorf("unknown number should failed")
+	}
+
+	if PhoneMetadataVersion == "" {
+		t.Errorf("embedded phone metadata should have version")
+	}
+}
@@ -0,0 +1,368 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ParamSeparator split checker name and param, "regex=^[a-z]+$"
+const ParamSeparator = "="
+
+// ruleBuilder build checker from tag param at plan compile time.
+// Param errors returned here are kept in the plan, so they are found once
+// for every type, not on every Validate.
+type ruleBuilder func(mv *Validation, param string) (ValidaterFunc, error)
+
+// Checkers need param or engine state, init by this pkg. no need rwlock
+var ruleBuilders = map[string]ruleBuilder{
+	RegexKey:    regexBuilder,
+	NotRegexKey: notRegexBuilder,
+
+	AfterKey:       timeLimitBuilder(AfterKey, func(tm, limit time.Time) bool { return tm.After(limit) }),
+	BeforeKey:      timeLimitBuilder(BeforeKey, func(tm, limit time.Time) bool { return tm.Before(limit) }),
+	PastKey:        pastBuilder,
+	FutureKey:      futureBuilder,
+	WithinKey:      withinBuilder,
+	MinAgeKey:      minAgeBuilder,
+	MinDurationKey: durationLimitBuilder(MinDurationKey, func(d, limit time.Duration) bool { return d >= limit }),
+	MaxDurationKey: durationLimitBuilder(MaxDurationKey, func(d, limit time.Duration) bool { return d <= limit }),
+
+	IPInKey:        ipInBuilder,
+	CountryCodeKey: countryCodeBuilder,
+	PhoneKey:       phoneBuilder,
+
+	EmailKey:          emailBuilder,
+	EmailDomainKey:    emailDomainBuilder,
+	EmailNotDomainKey: emailNotDomainBuilder,
+	EmailMXKey:        emailMXBuilder,
+	ResolvableHostKey: resolvableHostBuilder,
+
+	URLKey:          urlBuilder,
+	URLSchemesKey:   urlSchemesBuilder,
+	AllowedHostsKey: allowedHostsBuilder,
+
+	MinLenKey: lenBuilder(MinLenKey, func(n, limit int) bool { return n >= limit }),
+	MaxLenKey: lenBuilder(MaxLenKey, func(n, limit int) bool { return n <= limit }),
+	OneOfKey:  oneOfBuilder,
+}
+
+// Name used by checkers of this pkg, can't be added by AddValidater
+func isBuiltinRule(name string) bool {
+	return validatorsMap[name] != nil || ruleBuilders[name] != nil || crossCheckers[name] != nil
+}
+
+// RuleNames return names can be used in valid tag, builtin and added by
+// AddValidater, sorted. Used by tools such as validvet.
+func RuleNames() []string {
+	names := []string{RequiredKey}
+	for name := range validatorsMap {
+		if name != RequiredKey {
+			names = append(names, name)
+		}
+	}
+	for name := range ruleBuilders {
+		if validatorsMap[name] == nil {
+			names = append(names, name)
+		}
+	}
+	for name := range crossCheckers {
+		names = append(names, name)
+	}
+
+	customValidatorsMap.RLock()
+	for name := range customValidatorsMap.validatorsMap {
+		names = append(names, name)
+	}
+	customValidatorsMap.RUnlock()
+
+	sort.Strings(names)
+
+	return names
+}
+
+// CheckRule return error if rule is unknown or param is bad, same error as
+// reported by Validate. "min_len=x" return error without any value checked.
+func CheckRule(name, param string) error {
+	if name == RequiredKey {
+		return nil
+	}
+
+	return resolveRule(Generated.compileRule(tagRule{name: name, param: param}))
+}
+
+// Return compile error of rule, or error if no checker has its name
+func resolveRule(r *rule) error {
+	if r.err != nil || r.fn != nil || r.cross != nil || validatorsMap[r.name] != nil {
+		return r.err
+	}
+
+	if _, ok := customValidatorsMap.findValidater(r.name); !ok {
+		return fmt.Errorf("can't find checker for [%s]", r.name)
+	}
+
+	return nil
+}
+
+// TagRule one rule of valid tag, "regex=^a+$" => {Name: "regex", Param: "^a+$"}
+type TagRule struct {
+	Name  string
+	Param string
+}
+
+// ParseTag split valid tag to rules same as Validate, "-" or empty return nil.
+// Tools reading tags, such as validgen and validvet, use it.
+func ParseTag(tag string) []TagRule {
+	var out []TagRule
+	for _, tr := range parseTag(tag) {
+		out = append(out, TagRule{Name: tr.name, Param: tr.param})
+	}
+
+	return out
+}
+
+// IsCrossRule return true if rule compare field with other field, param is
+// name of the other field, such as "eqfield=Password"
+func IsCrossRule(name string) bool {
+	return crossCheckers[name] != nil
+}
+
+// tagRule one checker in valid tag, "regex=^a+$" => {"regex", "^a+$"}
+type tagRule struct {
+	name  string
+	param string
+}
+
+// rule compiled checker for field
+type rule struct {
+	tagRule
+	fn    ValidaterFunc // built by ruleBuilder, nil for ValidaterFunc in validatorsMap or customValidatorsMap
+	cross crossFunc     // cross field checker, param is other field
+	err   error         // compile error, reported when field is checked
+}
+
+// fieldPlan compiled rules for one struct field
+type fieldPlan struct {
+	index    []int               // index sequence for reflect.Value.FieldByIndex
+	field    reflect.StructField // promoted field has Index of its own struct
+	path     string              // name with embedded type names, "Base.ID"
+	embedded bool                // embedded struct, only check required, fields are promoted
+	required bool
+	rules    []*rule
+}
+
+// structPlan compiled rules for one struct type, cached by Validation
+type structPlan struct {
+	fields []*fieldPlan
+}
+
+// Split valid tag to rules in order, "-" or empty return nil
+func parseTag(opt string) []tagRule {
+	if len(opt) == 0 || opt == ValidIgnor {
+		return nil
+	}
+
+	var out []tagRule
+	for _, value := range strings.Split(opt, FuncSeparator) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		tr := tagRule{name: value}
+		if i := strings.Index(value, ParamSeparator); i >= 0 {
+			tr.name = strings.TrimSpace(value[:i])
+			tr.param = strings.TrimSpace(value[i+len(ParamSeparator):])
+		}
+		out = append(out, tr)
+	}
+
+	return out
+}
+
+// Return compiled plan for struct type t, compile it at first use
+func (mv *Validation) planFor(t reflect.Type) *structPlan {
+	mv.mu.Lock()
+	defer mv.mu.Unlock()
+
+	if mv.plans == nil {
+		mv.plans = make(map[reflect.Type]*structPlan)
+	}
+
+	if p, ok := mv.plans[t]; ok {
+		return p
+	}
+
+	p := mv.compileStruct(t)
+	mv.plans[t] = p
+
+	return p
+}
+
+// Compile fields of struct, fields of embedded structs are promoted by Go
+// visibility rules, outer field with same name hides promoted one.
+func (mv *Validation) compileStruct(t reflect.Type) *structPlan {
+	debugf("Compile struct [%s]", t.Name())
+
+	p := &structPlan{}
+	for _, tf := range reflect.VisibleFields(t) {
+		// Skip Anonymous and private field
+		if !tf.Anonymous && len(tf.PkgPath) > 0 {
+			continue
+		}
+
+		tag, path, ok := promotedTag(t, tf)
+
+		// Embedded struct with "-"
+		if !ok {
+			continue
+		}
+
+		fp := mv.compileField(tf, tag)
+
+		// Already skip ValidIgnor flag, such as "-"
+		if fp == nil {
+			continue
+		}
+
+		fp.index = tf.Index
+		fp.path = path
+		fp.embedded = isEmbeddedStruct(tf)
+		p.fields = append(p.fields, fp)
+	}
+
+	return p
+}
+
+// Embedded struct or struct ptr, fields are promoted
+func isEmbeddedStruct(tf reflect.StructField) bool {
+	t := tf.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return tf.Anonymous && t.Kind() == reflect.Struct && !isValueStruct(t)
+}
+
+// Return tag and path of field in struct t. Outer embedded field can
+// override tag of promoted field by "valid.Name" tag, outermost wins:
+//
+//	type User struct {
+//		Base `valid.ID:"-" valid.Name:"required;email"`
+//	}
+//
+// ok is false if any embedded struct on the way has valid:"-".
+func promotedTag(t reflect.Type, tf reflect.StructField) (tag string, path string, ok bool) {
+	tag = tf.Tag.Get(ValidTag)
+	path = tf.Name
+
+	for i := len(tf.Index) - 1; i > 0; i-- {
+		ef := t.FieldByIndex(tf.Index[:i])
+		if ef.Tag.Get(ValidTag) == ValidIgnor {
+			return "", "", false
+		}
+
+		if o, found := ef.Tag.Lookup(ValidTag + "." + tf.Name); found {
+			tag = o
+		}
+		path = ef.Name + "." + path
+	}
+
+	return tag, path, true
+}
+
+// Compile field tag, return nil if nothing to check
+func (mv *Validation) compileField(tf reflect.StructField, tag string) *fieldPlan {
+	trs := parseTag(tag)
+	if len(trs) == 0 {
+		return nil
+	}
+
+	fp := &fieldPlan{field: tf}
+	for _, tr := range trs {
+		if tr.name == RequiredKey {
+			fp.required = true
+			continue
+		}
+
+		fp.rules = append(fp.rules, mv.compileRule(tr))
+	}
+
+	return fp
+}
+
+func (mv *Validation) compileRule(tr tagRule) *rule {
+	r := &rule{tagRule: tr}
+
+	if cross, ok := crossCheckers[tr.name]; ok {
+		r.cross = cross
+		return r
+	}
+
+	if build, ok := ruleBuilders[tr.name]; ok {
+		r.fn, r.err = build(mv, tr.param)
+		if r.err != nil {
+			debugf("compile checker [%s] failed: %s", tr.name, r.err)
+		}
+		return r
+	}
+
+	r.err = noParam(tr.name, tr.param)
+
+	return r
+}
+
+// Run cross field checker, mismatch error names the other field in param
+func (r *rule) checkCross(v, other interface{}) error {
+	err := r.cross(v, other)
+	if fm, ok := err.(*ErrFieldMismatch); ok {
+		fm.Field = r.param
+	}
+
+	return err
+}
+
+// Return error if checker without param got one
+func noParam(name, param string) error {
+	if param != "" {
+		return fmt.Errorf("checker [%s] don't accept param [%s]", name, param)
+	}
+
+	return nil
+}
+
+// Run rule on value, o is struct of field or other value for cross field rules
+func (mv *Validation) checkRule(r *rule, v interface{}, o reflect.Value) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if r.cross != nil {
+		other, ok := otherValue(o, r.param)
+		if !ok {
+			return fmt.Errorf("can't find field [%s] for [%s]", r.param, r.name)
+		}
+		return r.checkCross(v, other)
+	}
+
+	if r.fn != nil {
+		return r.fn(v)
+	}
+
+	// find custom map and pkg map
+	var vck ValidaterFunc
+	var find bool
+
+	if vck, find = customValidatorsMap.findValidater(r.name); !find {
+		vck = validatorsMap[r.name]
+	}
+
+	if vck == nil {
+		debugf("can't find checker for [%s]", r.name)
+		return fmt.Errorf("can't find checker for [%s]", r.name)
+	}
+
+	return vck(v)
+}
@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestRuleNames(t *testing.T) {
+	names := RuleNames()
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("RuleNames() not sorted: %v", names)
+	}
+
+	set := make(map[string]bool)
+	for _, name := range names {
+		if set[name] {
+			t.Errorf("RuleNames() has duplicate [%s]", name)
+		}
+		set[name] = true
+	}
+
+	for _, name := range []string{RequiredKey, EmailKey, RegexKey, EqFieldKey, MinLenKey} {
+		if !set[name] {
+			t.Errorf("RuleNames() miss [%s]", name)
+		}
+	}
+}
+
+func TestCheckRule(t *testing.T) {
+	AddValidater("plan_custom", func(v interface{}) error { return nil })
+
+	tests := []struct {
+		name  string
+		param string
+		err   string
+	}{
+		{RequiredKey, "", ""},
+		{EmailKey, "", ""},
+		{EmailKey, "strict", ""},
+		{EmailKey, "bad", "unknown email option [bad]"},
+		{MinLenKey, "x", "min_len need non-negative int, but got [x]"},
+		{UUIDKey, "4", "checker [uuid] don't accept param [4]"},
+		{EqFieldKey, "Password", ""},
+		{"plan_custom", "", ""},
+		{"requried", "", "can't find checker for [requried]"},
+	}
+
+	for _, tt := range tests {
+		err := CheckRule(tt.name, tt.param)
+		if tt.err == "" && err != nil || tt.err != "" && (err == nil || err.Error() != tt.err) {
+			t.Errorf("CheckRule(%q, %q) = %v, want %q", tt.name, tt.param, err, tt.err)
+		}
+	}
+}
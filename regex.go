@@ -0,0 +1,186 @@
+package validation
+
+import (
+	"container/list"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Regex checker names, "regex=^[a-z]+$" or "not_regex=@zipcode" for pattern added by RegisterPattern.
+// Pattern can't contain FuncSeparator ";", register it by name instead.
+const (
+	RegexKey    = "regex"
+	NotRegexKey = "not_regex"
+
+	// Prefix of named pattern param, "regex=@zipcode"
+	PatternNamePrefix = "@"
+
+	// Max compiled patterns kept by one Validation
+	maxPatternCacheSize = 256
+)
+
+var (
+	// Named patterns shared by all Validation, using rwlock avoid race
+	namedPatterns = struct {
+		sync.RWMutex
+		m map[string]*regexp.Regexp
+	}{m: make(map[string]*regexp.Regexp)}
+)
+
+// RegisterPattern add named pattern, so tag can use "regex=@name".
+// Same name will be replaced, invalid pattern return compile error.
+// Name is looked up when checking, so it can be registered after first Validate.
+func RegisterPattern(name, pattern string) error {
+	rx, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+
+	namedPatterns.Lock()
+	namedPatterns.m[name] = rx
+	namedPatterns.Unlock()
+
+	return nil
+}
+
+// Return pattern added by RegisterPattern
+func findPattern(name string) (*regexp.Regexp, bool) {
+	namedPatterns.RLock()
+	rx, ok := namedPatterns.m[name]
+	namedPatterns.RUnlock()
+
+	return rx, ok
+}
+
+// patternCache bounded lru cache for compiled patterns
+type patternCache struct {
+	max   int
+	ll    *list.List
+	items map[string]*list.Element
+	sync.Mutex
+}
+
+type patternEntry struct {
+	pattern string
+	rx      *regexp.Regexp
+}
+
+func newPatternCache(max int) *patternCache {
+	return &patternCache{
+		max:   max,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Return compiled pattern, compile and cache it if missing
+func (pc *patternCache) get(pattern string) (*regexp.Regexp, error) {
+	pc.Lock()
+	defer pc.Unlock()
+
+	if e, ok := pc.items[pattern]; ok {
+		pc.ll.MoveToFront(e)
+		return e.Value.(*patternEntry).rx, nil
+	}
+
+	rx, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	pc.items[pattern] = pc.ll.PushFront(&patternEntry{pattern: pattern, rx: rx})
+	if pc.ll.Len() > pc.max {
+		e := pc.ll.Back()
+		pc.ll.Remove(e)
+		delete(pc.items, e.Value.(*patternEntry).pattern)
+	}
+
+	return rx, nil
+}
+
+// Return pattern name of "@name" param
+func patternName(param string) (string, bool) {
+	if !strings.HasPrefix(param, PatternNamePrefix) {
+		return "", false
+	}
+
+	return param[len(PatternNamePrefix):], true
+}
+
+// Return error if param of regex or not_regex is "@name" not registered,
+// Compile report it at boot, Validate only find it when checking
+func unknownPattern(name, param string) error {
+	if name != RegexKey && name != NotRegexKey {
+		return nil
+	}
+
+	pname, named := patternName(param)
+	if !named {
+		return nil
+	}
+
+	if _, ok := findPattern(pname); !ok {
+		return &ErrBadPattern{Pattern: param, Err: fmt.Errorf("can't find pattern [%s]", pname)}
+	}
+
+	return nil
+}
+
+// Return compiled param from engine cache, param is always a regex
+func (mv *Validation) pattern(param string) (*regexp.Regexp, error) {
+	if param == "" {
+		return nil, fmt.Errorf("regex checker need a pattern")
+	}
+
+	mv.patternsOnce.Do(func() {
+		mv.patterns = newPatternCache(maxPatternCacheSize)
+	})
+
+	return mv.patterns.get(param)
+}
+
+func regexBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	return buildRegex(mv, param, false)
+}
+
+func notRegexBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	return buildRegex(mv, param, true)
+}
+
+func buildRegex(mv *Validation, param string, negate bool) (ValidaterFunc, error) {
+	var rx *regexp.Regexp
+	name, named := patternName(param)
+	if named {
+		if name == "" {
+			return nil, &ErrBadPattern{Pattern: param, Err: fmt.Errorf("pattern name is empty")}
+		}
+	} else {
+		var err error
+		if rx, err = mv.pattern(param); err != nil {
+			return nil, &ErrBadPattern{Pattern: param, Err: err}
+		}
+	}
+
+	return func(v interface{}) error {
+		str, ok := v.(string)
+		if !ok {
+			return NewErrWrongType("string", v)
+		}
+
+		rx := rx
+		if named {
+			var found bool
+			if rx, found = findPattern(name); !found {
+				return &ErrBadPattern{Pattern: param, Err: fmt.Errorf("can't find pattern [%s]", name)}
+			}
+		}
+
+		if rx.MatchString(str) == negate {
+			return &ErrPatternMismatch{Pattern: param, Negate: negate}
+		}
+
+		return nil
+	}, nil
+}
@@ -0,0 +1,137 @@
+package validation
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRegex(t *testing.T) {
+	if err := RegisterPattern("zipcode", `^\d{6}$`); err != nil {
+		t.Fatalf("RegisterPattern should succeed. but got %s", err)
+	}
+
+	type Address struct {
+		Code   string   `valid:"regex=^[A-Z]{2}-[0-9]+$"`
+		Zip    string   `valid:"regex=@zipcode"`
+		Street string   `valid:"not_regex=[0-9]"`
+		Tags   []string `valid:"regex=^[a-z]+$"`
+	}
+
+	tests := []struct {
+		Addr   Address
+		Expect int
+	}{
+		{Address{"CN-10", "100080", "Main", []string{"a", "b"}}, 0},
+		{Address{"cn-10", "100080", "Main", nil}, 1},
+		{Address{"CN-10", "1000", "Main", nil}, 1},
+		{Address{"CN-10", "100080", "Main 1", nil}, 1},
+		{Address{"CN-10", "100080", "Main", []string{"a", "B1"}}, 1},
+		{Address{"", "", "1", nil}, 3},
+	}
+
+	validor := NewValidation()
+	for _, test := range tests {
+		validor.Reset()
+		validor.Validate(test.Addr)
+
+		if len(validor.Errs()) != test.Expect {
+			t.Errorf("Validate %+v should got [%d] errors, but got %s",
+				test.Addr, test.Expect, validor.ErrMsg())
+		}
+	}
+
+	err := AddValidater(RegexKey, upperChecker)
+	if err != ErrValidaterExists {
+		t.Errorf("AddValidater should failed [ErrValidaterExists]. but got %v", err)
+	}
+}
+
+func TestRegexBadPattern(t *testing.T) {
+	obj := struct {
+		Name string `valid:"regex=^[a-z+$"`
+	}{Name: "dave"}
+
+	validor := NewValidation()
+	for i := 0; i < 2; i++ {
+		validor.Reset()
+		if validor.Validate(obj) {
+			t.Fatalf("Validate should failed for bad pattern")
+		}
+
+		if _, ok := validor.Errs()[0].Err.(*ErrBadPattern); !ok {
+			t.Errorf("should got ErrBadPattern, but got %s", validor.ErrMsg())
+		}
+	}
+
+	if len(validor.plans) != 1 {
+		t.Errorf("plan should compiled once, but got %d plans", len(validor.plans))
+	}
+
+	if err := RegisterPattern("bad", "(a"); err == nil {
+		t.Errorf("RegisterPattern should failed for bad pattern")
+	}
+}
+
+func TestRegexNamedPattern(t *testing.T) {
+	type Item struct {
+		Code string `valid:"regex=@sku"`
+		Name string `valid:"regex=sku"`
+		Bad  string `valid:"regex=@"`
+	}
+
+	validor := NewValidation()
+	if validor.Validate(Item{Code: "AB12", Name: "sku"}) {
+		t.Fatalf("Validate should failed before pattern registered")
+	}
+
+	errs := validor.Errs()
+	if len(errs) != 2 || errs[0].FieldName != "Code" || errs[1].FieldName != "Bad" {
+		t.Fatalf("Code and Bad should be bad pattern, but got %s", validor.ErrMsg())
+	}
+	for _, e := range errs {
+		if _, ok := e.Err.(*ErrBadPattern); !ok {
+			t.Errorf("should got ErrBadPattern, but got %s", e.Err)
+		}
+	}
+
+	// literal regex is never replaced by pattern with same name
+	if err := RegisterPattern("sku", `^[A-Z]{2}[0-9]{2}$`); err != nil {
+		t.Fatalf("RegisterPattern should succeed. but got %s", err)
+	}
+
+	validor.Reset()
+	validor.Validate(Item{Code: "AB12", Name: "sku"})
+	if errs := validor.Errs(); len(errs) != 1 || errs[0].FieldName != "Bad" {
+		t.Errorf("only Bad should fail after pattern registered, but got %s", validor.ErrMsg())
+	}
+
+	validor.Reset()
+	validor.Validate(Item{Code: "ab12", Name: "x"})
+	if errs := validor.Errs(); len(errs) != 3 {
+		t.Errorf("Code, Name and Bad should fail, but got %s", validor.ErrMsg())
+	}
+}
+
+func TestPatternCache(t *testing.T) {
+	pc := newPatternCache(2)
+
+	for i := 0; i < 3; i++ {
+		if _, err := pc.get(fmt.Sprintf("^a{%d}$", i)); err != nil {
+			t.Fatalf("get pattern failed %s", err)
+		}
+	}
+
+	if pc.ll.Len() != 2 || len(pc.items) != 2 {
+		t.Errorf("cache should keep 2 patterns, but got %d", pc.ll.Len())
+	}
+
+	if _, ok := pc.items["^a{0}$"]; ok {
+		t.Errorf("oldest pattern should be evicted")
+	}
+
+	rx1, _ := pc.get("^a{2}$")
+	rx2, _ := pc.get("^a{2}$")
+	if rx1 != rx2 {
+		t.Errorf("cached pattern should be reused")
+	}
+}
@@ -0,0 +1,33 @@
+package validation
+
+import "reflect"
+
+// structReporter Reporter for StructValidater, add errors to Validation
+type structReporter struct {
+	mv *Validation
+	v  reflect.Value // struct value
+}
+
+// Report add err on field, nil err is ignored
+func (r *structReporter) Report(field string, err error) {
+	if err == nil {
+		return
+	}
+
+	r.mv.addError(field, fieldValue(r.v, field), err)
+}
+
+// Return value of field by name, nil if not found or promoted through nil
+// embedded ptr
+func fieldValue(v reflect.Value, field string) interface{} {
+	sf, ok := v.Type().FieldByName(field)
+	if !ok {
+		return nil
+	}
+
+	if f, err := v.FieldByIndexErr(sf.Index); err == nil && f.CanInterface() {
+		return f.Interface()
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type Booking struct {
+	Name      string    `valid:"required"`
+	StartDate time.Time `valid:"required"`
+	EndDate   time.Time `valid:"required"`
+	Rooms     int
+}
+
+var errEndBeforeStart = errors.New("EndDate must be after StartDate")
+
+func (b Booking) ValidateStruct(r Reporter) {
+	if !b.EndDate.After(b.StartDate) {
+		r.Report("EndDate", errEndBeforeStart)
+	}
+
+	if b.Rooms <= 0 {
+		r.Report("Rooms", errors.New("at least one room"))
+	}
+
+	r.Report("Name", nil)
+}
+
+func TestStructValidater(t *testing.T) {
+	start := time.Date(2018, 2, 8, 0, 0, 0, 0, time.UTC)
+	b := &Booking{Name: "dave", StartDate: start, EndDate: start.Add(-time.Hour)}
+
+	validor := NewValidation()
+	if validor.Validate(b) {
+		t.Fatalf("Validate should failed")
+	}
+
+	errs := validor.Errs()
+	if len(errs) != 2 {
+		t.Fatalf("should got 2 errors, but got %s", validor.ErrMsg())
+	}
+
+	if errs[0].FieldName != "EndDate" || errs[0].Err != errEndBeforeStart || errs[0].Value != b.EndDate {
+		t.Errorf("error should attach to EndDate, but got %s", errs[0].String())
+	}
+
+	if errs[1].FieldName != "Rooms" || errs[1].Value != 0 {
+		t.Errorf("error should attach to Rooms, but got %s", errs[1].String())
+	}
+
+	validor.Reset()
+	b.EndDate = start.Add(time.Hour)
+	b.Rooms = 1
+	if !validor.Validate(b) {
+		t.Errorf("Validate should succeed, but got %s", validor.ErrMsg())
+	}
+}
+
+type ReportBase struct {
+	ID int
+}
+
+type ReportUser struct {
+	*ReportBase
+	Name string
+}
+
+func (u ReportUser) ValidateStruct(r Reporter) {
+	r.Report("ID", errors.New("id is not set"))
+}
+
+func TestStructValidaterNilEmbedded(t *testing.T) {
+	validor := NewValidation()
+	if validor.Validate(&ReportUser{Name: "dave"}) {
+		t.Fatalf("Validate should failed")
+	}
+
+	errs := validor.Errs()
+	if len(errs) != 1 || errs[0].FieldName != "ID" || errs[0].Value != nil {
+		t.Errorf("error should attach to ID with nil value, but got %s", validor.ErrMsg())
+	}
+
+	validor.Reset()
+	validor.Validate(&ReportUser{ReportBase: &ReportBase{ID: 7}})
+	if errs := validor.Errs(); len(errs) != 1 || errs[0].Value != 7 {
+		t.Errorf("error should attach to ID with value 7, but got %s", validor.ErrMsg())
+	}
+}
@@ -0,0 +1,143 @@
+package validation
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Schema describe map[string]interface{} document, such as decoded JSON,
+// Rules are same as valid tag of struct field.
+//
+//	schema := &validation.Schema{Fields: map[string]*validation.Schema{
+//		"email":   {Rules: "required;email"},
+//		"confirm": {Rules: "eqfield=email"},
+//		"address": {Rules: "required", Fields: map[string]*validation.Schema{
+//			"city": {Rules: "required"},
+//		}},
+//		"tags": {Items: &validation.Schema{Rules: "regex=^[a-z]+$"}},
+//	}}
+//
+// Errors are reported by path of key like struct errors with SetEmbeddedPath,
+// "address.city", elements of array by path of array, "tags" and "items.sku".
+//
+// Values are checked as they are in doc, numbers decoded by encoding/json are
+// always float64, so rules need int such as port report wrong type on them.
+// Object or array without Fields or Items is checked as whole value only.
+type Schema struct {
+	Rules  string             // valid tag of value, checked on whole object or array
+	Fields map[string]*Schema // keys of object value, value should be map
+	Items  *Schema            // elements of array value, value should be slice or array
+}
+
+// ValidateMap check document by fields of schema, keys not in schema are ignored.
+// Cross field rules of a key compare with other key of same object.
+func (mv *Validation) ValidateMap(doc map[string]interface{}, schema *Schema) bool {
+	if schema == nil {
+		return !mv.HasError()
+	}
+
+	// Top level call, new object graph
+	if mv.depth == 0 {
+		mv.visited = make(map[visitKey]bool)
+	}
+
+	mv.validateObject("", reflect.ValueOf(doc), schema)
+
+	return !mv.HasError()
+}
+
+// Check keys of object in schema order
+func (mv *Validation) validateObject(path string, obj reflect.Value, s *Schema) {
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := reflect.ValueOf(name).Convert(obj.Type().Key())
+		mv.validateNode(joinPath(path, name), obj.MapIndex(key), obj, s.Fields[name])
+	}
+}
+
+// Check value of key or element, invalid v for missing key, o is object of key
+func (mv *Validation) validateNode(path string, v reflect.Value, o reflect.Value, s *Schema) {
+	if s == nil {
+		return
+	}
+
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			v = reflect.Value{}
+		} else {
+			v = v.Elem()
+		}
+	}
+
+	fp := mv.schemaPlan(s.Rules, path)
+
+	// Missing key or null, only required can be checked
+	if !v.IsValid() {
+		if fp.required {
+			mv.addError(path, nil, ErrRequired)
+		}
+		return
+	}
+
+	switch {
+	case s.Fields != nil:
+		if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+			mv.addError(path, v.Interface(), NewErrWrongType("object", v.Interface()))
+			return
+		}
+
+		mv.checkWhole(v, fp, o)
+		mv.validateObject(path, v, s)
+
+	case s.Items != nil:
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			mv.addError(path, v.Interface(), NewErrWrongType("array", v.Interface()))
+			return
+		}
+
+		mv.checkWhole(v, fp, o)
+		for i := 0; i < v.Len(); i++ {
+			mv.validateNode(path, v.Index(i), o, s.Items)
+		}
+
+	case v.Kind() == reflect.Map || v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		// No schema of keys or elements, don't walk into them
+		mv.checkWhole(v, fp, o)
+
+	default:
+		mv.typeCheck(v, fp, o, false)
+	}
+}
+
+// Check required and rules on object or array, not on its elements
+func (mv *Validation) checkWhole(v reflect.Value, fp *fieldPlan, o reflect.Value) {
+	if fp.required {
+		if err := mv.checkRequire(v, fp.field); err != nil {
+			mv.addError(mv.fieldName(fp), v.Interface(), err)
+		}
+	}
+
+	mv.checkValue(v, fp, o)
+}
+
+// Return plan of rules reported by path, rules compiled once and cached
+func (mv *Validation) schemaPlan(rules, path string) *fieldPlan {
+	fp := *mv.varPlan(rules)
+	fp.field.Name = path
+	fp.path = path
+
+	return &fp
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
@@ -0,0 +1,137 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var orderSchema = &Schema{Fields: map[string]*Schema{
+	"email":   {Rules: "required;email"},
+	"confirm": {Rules: "eqfield=email"},
+	"address": {Rules: "required", Fields: map[string]*Schema{
+		"city": {Rules: "required"},
+		"zip":  {Rules: "regex=^[0-9]{6}$"},
+	}},
+	"tags": {Items: &Schema{Rules: "regex=^[a-z]+$"}},
+	"items": {Rules: "required", Items: &Schema{Fields: map[string]*Schema{
+		"sku": {Rules: "required"},
+	}}},
+}}
+
+func TestValidateMap(t *testing.T) {
+	tests := []struct {
+		doc    string
+		fields []string
+	}{
+		{`{"email": "dave@do1618.com", "confirm": "dave@do1618.com", "address": {"city": "Beijing", "zip": "100000"},
+			"tags": ["a", "b"], "items": [{"sku": "x1"}]}`, nil},
+		{`{}`, []string{"address", "email", "items"}},
+		{`{"email": null, "address": {"zip": "1"}, "items": [{"sku": ""}, {}]}`,
+			[]string{"address.city", "address.zip", "email", "items.sku", "items.sku"}},
+		{`{"email": "dave", "confirm": "other", "address": "Beijing", "tags": ["a", "B"], "items": {}}`,
+			[]string{"address", "confirm", "email", "items", "tags"}},
+	}
+
+	validor := NewValidation()
+	for _, test := range tests {
+		var doc map[string]interface{}
+		if err := json.Unmarshal([]byte(test.doc), &doc); err != nil {
+			t.Fatal(err)
+		}
+
+		validor.Reset()
+		ok := validor.ValidateMap(doc, orderSchema)
+		if ok != (len(test.fields) == 0) {
+			t.Errorf("ValidateMap(%s) got %v: %s", test.doc, ok, validor.ErrMsg())
+			continue
+		}
+
+		if len(validor.Errs()) != len(test.fields) {
+			t.Errorf("ValidateMap(%s) should got errors on %v, but got %s", test.doc, test.fields, validor.ErrMsg())
+			continue
+		}
+
+		for i, name := range test.fields {
+			if validor.Errs()[i].FieldName != name {
+				t.Errorf("ValidateMap(%s) error %d should be on %s, but got %s", test.doc, i, name, validor.Errs()[i].FieldName)
+			}
+		}
+	}
+
+	validor.Reset()
+	validor.ValidateMap(map[string]interface{}{"address": []interface{}{}}, orderSchema)
+	var wrong *ErrWrongExpectType
+	if !errors.As(validor.Errs()[0].Err, &wrong) || wrong.ExpectType != "object" {
+		t.Errorf("should got wrong type error on address, but got %s", validor.ErrMsg())
+	}
+}
+
+func TestValidateMapNumbers(t *testing.T) {
+	var got []interface{}
+	AddValidater("test_number", func(v interface{}) error {
+		got = append(got, v)
+		return nil
+	})
+
+	schema := &Schema{Fields: map[string]*Schema{
+		"a":    {Rules: "test_number"},
+		"b":    {Rules: "test_number"},
+		"port": {Rules: "port"},
+	}}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"a": 3, "b": 3.5, "port": 8080}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	validor := NewValidation()
+	validor.ValidateMap(doc, schema)
+
+	if !reflect.DeepEqual(got, []interface{}{3.0, 3.5}) {
+		t.Errorf("numbers should be float64, but got %#v", got)
+	}
+
+	var wrong *ErrWrongExpectType
+	if errs := validor.Errs(); len(errs) != 1 || errs[0].FieldName != "port" || !errors.As(errs[0].Err, &wrong) {
+		t.Errorf("port should got wrong type error, but got %s", validor.ErrMsg())
+	}
+}
+
+func TestValidateMapWhole(t *testing.T) {
+	schema := &Schema{Fields: map[string]*Schema{
+		"meta": {Rules: "required"},
+		"tags": {Rules: "required"},
+	}}
+
+	tests := []struct {
+		doc    string
+		fields []string
+	}{
+		{`{"meta": {"a": 0}, "tags": [0, ""]}`, nil},
+		{`{"meta": null, "tags": null}`, []string{"meta", "tags"}},
+		{`{}`, []string{"meta", "tags"}},
+	}
+
+	validor := NewValidation()
+	for _, test := range tests {
+		var doc map[string]interface{}
+		if err := json.Unmarshal([]byte(test.doc), &doc); err != nil {
+			t.Fatal(err)
+		}
+
+		validor.Reset()
+		validor.ValidateMap(doc, schema)
+
+		var got []string
+		for _, err := range validor.Errs() {
+			got = append(got, err.FieldName)
+		}
+
+		if strings.Join(got, ",") != strings.Join(test.fields, ",") {
+			t.Errorf("ValidateMap(%s) should got errors on %v, but got %s", test.doc, test.fields, validor.ErrMsg())
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Range operators accept by semver_range, longer first
+var semverOperators = []string{">=", "<=", ">", "<", "=", "^", "~"}
+
+func semverError(reason string, args ...interface{}) error {
+	return &ErrBadIdentifier{Kind: SemverKey, Reason: fmt.Sprintf(reason, args...)}
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Check dot separated identifiers of pre-release or build
+func checkSemverIdents(part, str string, numericNoZero bool) error {
+	for _, id := range strings.Split(str, ".") {
+		if id == "" {
+			return semverError("empty %s identifier", part)
+		}
+
+		for i := 0; i < len(id); i++ {
+			c := id[i]
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+				return semverError("bad char %q in %s identifier [%s]", c, part, id)
+			}
+		}
+
+		if numericNoZero && isNumeric(id) && len(id) > 1 && id[0] == '0' {
+			return semverError("numeric %s identifier [%s] has leading zero", part, id)
+		}
+	}
+
+	return nil
+}
+
+// Check numeric version part, wildcard "x", "X", "*" only for range
+func checkSemverNumber(name, s string, wildcard bool) (bool, error) {
+	if wildcard && (s == "x" || s == "X" || s == "*") {
+		return true, nil
+	}
+
+	if !isNumeric(s) {
+		return false, semverError("%s version [%s] is not a number", name, s)
+	}
+
+	if len(s) > 1 && s[0] == '0' {
+		return false, semverError("%s version [%s] has leading zero", name, s)
+	}
+
+	return false, nil
+}
+
+// Parse version by semver 2.0.0, partial allow "1", "1.2" and wildcards for range
+func checkSemver(str string, partial bool) error {
+	if str == "" {
+		return semverError("empty version")
+	}
+
+	if i := strings.IndexByte(str, '+'); i >= 0 {
+		if err := checkSemverIdents("build", str[i+1:], false); err != nil {
+			return err
+		}
+		str = str[:i]
+	}
+
+	core, pre := str, ""
+	if i := strings.IndexByte(str, '-'); i >= 0 {
+		core, pre = str[:i], str[i+1:]
+		if err := checkSemverIdents("pre-release", pre, true); err != nil {
+			return err
+		}
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 || (!partial && len(parts) != 3) {
+		return semverError("version core [%s] should be MAJOR.MINOR.PATCH", core)
+	}
+
+	names := []string{"major", "minor", "patch"}
+	wild := false
+	for i, p := range parts {
+		isWild, err := checkSemverNumber(names[i], p, partial)
+		if err != nil {
+			return err
+		}
+
+		if wild && !isWild {
+			return semverError("%s version [%s] after wildcard", names[i], p)
+		}
+		wild = wild || isWild
+	}
+
+	if pre != "" && (wild || len(parts) != 3) {
+		return semverError("pre-release [%s] need full version", pre)
+	}
+
+	return nil
+}
+
+// Check range like ">=1.2.3 <2.0.0 || ^3.1 || 4.x || 1.0.0 - 1.2.0"
+func checkSemverRange(str string) error {
+	if strings.TrimSpace(str) == "" {
+		return &ErrBadIdentifier{Kind: SemverRangeKey, Reason: "empty range"}
+	}
+
+	for _, set := range strings.Split(str, "||") {
+		set = strings.TrimSpace(set)
+		if set == "" {
+			return &ErrBadIdentifier{Kind: SemverRangeKey, Reason: "empty comparator set around ||"}
+		}
+
+		if ends := strings.Split(set, " - "); len(ends) > 1 {
+			if len(ends) != 2 {
+				return &ErrBadIdentifier{Kind: SemverRangeKey, Reason: fmt.Sprintf("bad hyphen range [%s]", set)}
+			}
+			for _, end := range ends {
+				if err := checkSemver(strings.TrimSpace(end), true); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		fields := strings.Fields(set)
+		for i := 0; i < len(fields); i++ {
+			f := fields[i]
+			for _, op := range semverOperators {
+				if strings.HasPrefix(f, op) {
+					f = f[len(op):]
+					break
+				}
+			}
+
+			// Operator separated by space, such as ">= 1.2.3"
+			if f == "" {
+				if i+1 == len(fields) {
+					return &ErrBadIdentifier{Kind: SemverRangeKey, Reason: fmt.Sprintf("operator [%s] without version", fields[i])}
+				}
+				i++
+				f = fields[i]
+			}
+
+			if err := checkSemver(f, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func semverChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	return checkSemver(str, false)
+}
+
+func semverRangeChecker(v interface{}) error {
+	str, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+
+	return checkSemverRange(str)
+}
@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Text checker names
+//
+//	min_len=3          string has at least 3 characters, counted in runes
+//	max_len=20         string has at most 20 characters
+//	oneof=red,green    value in comma separated list, numbers are compared by text
+const (
+	MinLenKey = "min_len"
+	MaxLenKey = "max_len"
+	OneOfKey  = "oneof"
+)
+
+// Return builder for min_len and max_len, cmp return true if length passed
+func lenBuilder(name string, cmp func(n, limit int) bool) ruleBuilder {
+	return func(mv *Validation, param string) (ValidaterFunc, error) {
+		limit, err := strconv.Atoi(param)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("%s need non-negative int, but got [%s]", name, param)
+		}
+
+		return func(v interface{}) error {
+			str, err := stringValue(v)
+			if err != nil {
+				return err
+			}
+
+			if !cmp(utf8.RuneCountInString(str), limit) {
+				return &ErrLength{Rule: name, Limit: limit}
+			}
+
+			return nil
+		}, nil
+	}
+}
+
+// Split oneof param, values are not lower cased
+func oneOfValues(param string) []string {
+	var values []string
+	seen := make(map[string]bool)
+	for _, s := range strings.Split(param, ",") {
+		if s = strings.TrimSpace(s); s != "" && !seen[s] {
+			seen[s] = true
+			values = append(values, s)
+		}
+	}
+
+	return values
+}
+
+func oneOfBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	values := oneOfValues(param)
+	if len(values) == 0 {
+		return nil, fmt.Errorf("oneof need value list")
+	}
+
+	set := make(map[string]bool)
+	for _, s := range values {
+		set[s] = true
+	}
+
+	return func(v interface{}) error {
+		if kindClass(reflect.ValueOf(v)) == "" {
+			return NewErrWrongType("string or number", v)
+		}
+
+		if !set[fmt.Sprint(v)] {
+			return &ErrNotOneOf{Values: values}
+		}
+
+		return nil
+	}, nil
+}
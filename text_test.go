@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTextCheckers(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		tag   string
+		ok    bool
+	}{
+		{"abc", "min_len=3", true},
+		{"ab", "min_len=3", false},
+		{"你好世界", "max_len=4", true},
+		{"你好世界!", "max_len=4", false},
+		{"abc", "min_len=x", false},
+		{"abc", "max_len=-1", false},
+		{5, "min_len=1", false},
+		{"red", "oneof=red,green", true},
+		{"blue", "oneof=red, green", false},
+		{"Red", "oneof=red,green", false},
+		{2, "oneof=1,2,3", true},
+		{4, "oneof=1,2,3", false},
+		{"a", "oneof=", false},
+		{"red", "oneof=red,green,red", true},
+		{[]string{"red", "green"}, "oneof=red,green", true},
+	}
+
+	validor := NewValidation()
+	for _, test := range tests {
+		validor.Reset()
+		if ok := validor.ValidateVar(test.value, test.tag); ok != test.ok {
+			t.Errorf("ValidateVar(%#v, %q) should be %v, but got %v: %s", test.value, test.tag, test.ok, ok, validor.ErrMsg())
+		}
+	}
+}
+
+func TestOneOfDuplicated(t *testing.T) {
+	validor := NewValidation()
+	validor.ValidateVar("blue", "oneof=red,green,red")
+
+	var notOneOf *ErrNotOneOf
+	if len(validor.Errs()) != 1 || !errors.As(validor.Errs()[0].Err, &notOneOf) ||
+		!reflect.DeepEqual(notOneOf.Values, []string{"red", "green"}) {
+		t.Errorf("should got ErrNotOneOf with [red green], but got %s", validor.ErrMsg())
+	}
+}
@@ -0,0 +1,220 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Time checker names
+//
+//	after=2018-01-02     time.Time after limit, RFC3339 or date, "now" for clock
+//	before=now           time.Time before limit
+//	past                 time.Time before clock
+//	future               time.Time after clock
+//	within=720h          time.Time no more than duration away from clock
+//	min_age=18           birthdate at least n years before clock
+//	min_duration=1s      time.Duration not less than limit
+//	max_duration=1h      time.Duration not more than limit
+const (
+	AfterKey       = "after"
+	BeforeKey      = "before"
+	PastKey        = "past"
+	FutureKey      = "future"
+	WithinKey      = "within"
+	MinAgeKey      = "min_age"
+	MinDurationKey = "min_duration"
+	MaxDurationKey = "max_duration"
+
+	nowParam = "now"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+
+	// Layouts accept by after and before
+	timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"}
+)
+
+// Struct types checked as value, not walked for fields
+func isValueStruct(t reflect.Type) bool {
+	return t == timeType
+}
+
+// SetClock replace time.Now for time checkers, nil reset to time.Now.
+// Call it before Validate, mostly used by tests.
+func (mv *Validation) SetClock(now func() time.Time) {
+	mv.clock = now
+}
+
+// Return current time from clock
+func (mv *Validation) now() time.Time {
+	if mv.clock != nil {
+		return mv.clock()
+	}
+
+	return time.Now()
+}
+
+// Parse time limit in tag, try timeLayouts in order
+func parseTimeParam(param string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if tm, err := time.Parse(layout, param); err == nil {
+			return tm, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("bad time param [%s]", param)
+}
+
+func timeValue(v interface{}) (time.Time, error) {
+	tm, ok := v.(time.Time)
+	if !ok {
+		return tm, NewErrWrongType("time.Time", v)
+	}
+
+	return tm, nil
+}
+
+func durationValue(v interface{}) (time.Duration, error) {
+	d, ok := v.(time.Duration)
+	if !ok {
+		return d, NewErrWrongType("time.Duration", v)
+	}
+
+	return d, nil
+}
+
+// Return builder for after and before, cmp return true if value passed
+func timeLimitBuilder(name string, cmp func(tm, limit time.Time) bool) ruleBuilder {
+	return func(mv *Validation, param string) (ValidaterFunc, error) {
+		var limit time.Time
+		if param != nowParam {
+			var err error
+			if limit, err = parseTimeParam(param); err != nil {
+				return nil, err
+			}
+		}
+
+		return func(v interface{}) error {
+			tm, err := timeValue(v)
+			if err != nil {
+				return err
+			}
+
+			l := limit
+			if param == nowParam {
+				l = mv.now()
+			}
+
+			if !cmp(tm, l) {
+				return &ErrTimeRange{Rule: name, Limit: param}
+			}
+
+			return nil
+		}, nil
+	}
+}
+
+func pastBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	if err := noParam(PastKey, param); err != nil {
+		return nil, err
+	}
+
+	return func(v interface{}) error {
+		tm, err := timeValue(v)
+		if err != nil {
+			return err
+		}
+
+		if !tm.Before(mv.now()) {
+			return &ErrTimeRange{Rule: PastKey}
+		}
+
+		return nil
+	}, nil
+}
+
+func futureBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	if err := noParam(FutureKey, param); err != nil {
+		return nil, err
+	}
+
+	return func(v interface{}) error {
+		tm, err := timeValue(v)
+		if err != nil {
+			return err
+		}
+
+		if !tm.After(mv.now()) {
+			return &ErrTimeRange{Rule: FutureKey}
+		}
+
+		return nil
+	}, nil
+}
+
+func withinBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	d, err := time.ParseDuration(param)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(v interface{}) error {
+		tm, err := timeValue(v)
+		if err != nil {
+			return err
+		}
+
+		diff := mv.now().Sub(tm)
+		if diff < -d || diff > d {
+			return &ErrTimeRange{Rule: WithinKey, Limit: param}
+		}
+
+		return nil
+	}, nil
+}
+
+func minAgeBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	years, err := strconv.Atoi(param)
+	if err != nil || years < 0 {
+		return nil, fmt.Errorf("bad age param [%s]", param)
+	}
+
+	return func(v interface{}) error {
+		birth, err := timeValue(v)
+		if err != nil {
+			return err
+		}
+
+		if birth.AddDate(years, 0, 0).After(mv.now()) {
+			return &ErrTimeRange{Rule: MinAgeKey, Limit: param}
+		}
+
+		return nil
+	}, nil
+}
+
+// Return builder for duration bounds, cmp return true if value passed
+func durationLimitBuilder(name string, cmp func(d, limit time.Duration) bool) ruleBuilder {
+	return func(mv *Validation, param string) (ValidaterFunc, error) {
+		limit, err := time.ParseDuration(param)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(v interface{}) error {
+			d, err := durationValue(v)
+			if err != nil {
+				return err
+			}
+
+			if !cmp(d, limit) {
+				return &ErrTimeRange{Rule: name, Limit: param}
+			}
+
+			return nil
+		}, nil
+	}
+}
@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeCheckers(t *testing.T) {
+	now := time.Date(2018, 2, 8, 12, 0, 0, 0, time.UTC)
+
+	type Event struct {
+		Start    time.Time     `valid:"required;after=2018-01-01;before=now"`
+		End      *time.Time    `valid:"future;within=720h"`
+		Birthday time.Time     `valid:"past;min_age=18"`
+		Timeout  time.Duration `valid:"min_duration=1s;max_duration=1m"`
+		History  []time.Time   `valid:"past"`
+	}
+
+	end := now.Add(24 * time.Hour)
+	far := now.Add(24 * 31 * time.Hour)
+	birth := time.Date(2000, 2, 8, 0, 0, 0, 0, time.UTC)
+	young := time.Date(2000, 2, 9, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		Event  Event
+		Expect int
+	}{
+		{Event{now.Add(-time.Hour), &end, birth, time.Second, []time.Time{birth}}, 0},
+		{Event{time.Time{}, &end, birth, time.Second, nil}, 2},
+		{Event{now.Add(time.Hour), &end, birth, time.Second, nil}, 1},
+		{Event{now.Add(-time.Hour), &far, birth, time.Second, nil}, 1},
+		{Event{now.Add(-time.Hour), nil, young, time.Second, nil}, 1},
+		{Event{now.Add(-time.Hour), &end, birth, time.Hour, []time.Time{end}}, 2},
+	}
+
+	validor := NewValidation()
+	validor.SetClock(func() time.Time { return now })
+
+	for i, test := range tests {
+		validor.Reset()
+		validor.Validate(test.Event)
+
+		if len(validor.Errs()) != test.Expect {
+			t.Errorf("case %d should got [%d] errors, but got %s",
+				i, test.Expect, validor.ErrMsg())
+		}
+	}
+}
+
+func TestTimeBadParam(t *testing.T) {
+	obj := struct {
+		Start time.Time `valid:"after=yesterday"`
+		Age   time.Time `valid:"min_age=x"`
+		Past  time.Time `valid:"past=1h"`
+		Name  string    `valid:"past"`
+	}{Name: "dave"}
+
+	validor := NewValidation()
+	validor.Validate(obj)
+
+	if len(validor.Errs()) != 4 {
+		t.Errorf("should got 4 errors, but got %s", validor.ErrMsg())
+	}
+}
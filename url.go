@@ -0,0 +1,206 @@
+package validation
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// URL checker names, all parsed by net/url
+//
+//	url                          match URL pattern, scheme-less host like "www.baidu.com" is allowed
+//	url=https                    absolute url with host, scheme in comma separated list
+//	url_schemes=http,https,s3    scheme in list, scheme is required
+//	uri                          absolute uri, scheme is required, host is not
+//	http_url                     http or https url with host
+//	require_host                 url has host
+//	no_userinfo                  url has no "user:pass@"
+//	no_ip_host                   host is not ip address
+//	allowed_hosts=*.example.com  host in comma separated list, "*." for subdomains
+const (
+	URLKey          = "url"
+	URLSchemesKey   = "url_schemes"
+	URIKey          = "uri"
+	HTTPURLKey      = "http_url"
+	RequireHostKey  = "require_host"
+	NoUserinfoKey   = "no_userinfo"
+	NoIPHostKey     = "no_ip_host"
+	AllowedHostsKey = "allowed_hosts"
+)
+
+// Parse url for checkers, length limits same as urlChecker
+func parseURL(v interface{}) (*url.URL, error) {
+	str, err := stringValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if str == "" || utf8.RuneCountInString(str) >= maxURLRuneCount {
+		return nil, &ErrBadURL{Reason: ErrURLLength}
+	}
+
+	u, err := url.Parse(str)
+	if err != nil {
+		return nil, &ErrBadURL{Reason: ErrURLUnparsable, Detail: err.Error()}
+	}
+
+	return u, nil
+}
+
+// Split lower case comma separated list
+func splitList(param string) []string {
+	var out []string
+	for _, s := range strings.Split(strings.ToLower(param), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
+func checkScheme(u *url.URL, schemes []string) error {
+	if u.Scheme == "" {
+		return &ErrBadURL{Reason: ErrURLNoScheme}
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	for _, s := range schemes {
+		if scheme == s {
+			return nil
+		}
+	}
+
+	return &ErrBadURL{Reason: ErrURLScheme, Detail: u.Scheme}
+}
+
+func checkHost(u *url.URL) error {
+	if u.Hostname() == "" {
+		return &ErrBadURL{Reason: ErrURLNoHost}
+	}
+
+	return nil
+}
+
+// Return "url" checker, without param is urlChecker
+func urlBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	if param == "" {
+		return urlChecker, nil
+	}
+
+	schemes := splitList(param)
+	return func(v interface{}) error {
+		u, err := parseURL(v)
+		if err != nil {
+			return err
+		}
+
+		if err := checkScheme(u, schemes); err != nil {
+			return err
+		}
+
+		return checkHost(u)
+	}, nil
+}
+
+func urlSchemesBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	schemes := splitList(param)
+	if len(schemes) == 0 {
+		return nil, fmt.Errorf("url_schemes need scheme list")
+	}
+
+	return func(v interface{}) error {
+		u, err := parseURL(v)
+		if err != nil {
+			return err
+		}
+
+		return checkScheme(u, schemes)
+	}, nil
+}
+
+func uriChecker(v interface{}) error {
+	u, err := parseURL(v)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme == "" {
+		return &ErrBadURL{Reason: ErrURLNoScheme}
+	}
+
+	return nil
+}
+
+func httpURLChecker(v interface{}) error {
+	u, err := parseURL(v)
+	if err != nil {
+		return err
+	}
+
+	if err := checkScheme(u, []string{"http", "https"}); err != nil {
+		return err
+	}
+
+	return checkHost(u)
+}
+
+func requireHostChecker(v interface{}) error {
+	u, err := parseURL(v)
+	if err != nil {
+		return err
+	}
+
+	return checkHost(u)
+}
+
+func noUserinfoChecker(v interface{}) error {
+	u, err := parseURL(v)
+	if err != nil {
+		return err
+	}
+
+	if u.User != nil {
+		return &ErrBadURL{Reason: ErrURLUserinfo}
+	}
+
+	return nil
+}
+
+func noIPHostChecker(v interface{}) error {
+	u, err := parseURL(v)
+	if err != nil {
+		return err
+	}
+
+	if _, err := netip.ParseAddr(u.Hostname()); err == nil {
+		return &ErrBadURL{Reason: ErrURLIPHost, Detail: u.Hostname()}
+	}
+
+	return nil
+}
+
+func allowedHostsBuilder(mv *Validation, param string) (ValidaterFunc, error) {
+	hosts := splitList(param)
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("allowed_hosts need host list")
+	}
+
+	return func(v interface{}) error {
+		u, err := parseURL(v)
+		if err != nil {
+			return err
+		}
+
+		host := strings.ToLower(u.Hostname())
+		for _, h := range hosts {
+			if host == h || strings.HasPrefix(h, "*.") && strings.HasSuffix(host, h[1:]) {
+				return nil
+			}
+		}
+
+		return &ErrBadURL{Reason: ErrURLHost, Detail: host}
+	}, nil
+}
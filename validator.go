@@ -4,7 +4,7 @@ Package validation implements a simple library for struct tag validte.
 	1. Use interface for Validater
 	2. Support User define Validater
 	3. Support Struct define validater interface
-	4. Support slice/array/pointer and netestd struct validate. Not for map now!
+	4. Support slice/array/map/pointer and netestd struct validate.
 */
 package validation
 
@@ -13,8 +13,9 @@ import (
 	"fmt"
 	"log"
 	"reflect"
-	"strings"
+	"sort"
 	"sync"
+	"time"
 )
 
 // ex01 simple use
@@ -72,6 +73,20 @@ type Validater interface {
 	Validater() error
 }
 
+// StructValidater Interface for struct need report errors on many fields,
+// such as "EndDate must be after StartDate" on EndDate.
+// Errors reported look the same as errors of valid tags.
+type StructValidater interface {
+	ValidateStruct(r Reporter)
+}
+
+// Reporter attach struct level errors to fields
+type Reporter interface {
+	// Report err on field, field is name of struct field or any path,
+	// value of struct field is recorded if found
+	Report(field string, err error)
+}
+
 // ValidaterFunc type
 type ValidaterFunc func(v interface{}) error
 
@@ -86,14 +101,54 @@ const (
 	FuncSeparator = ";"        // Func sparator "required;email"
 	ValidIgnor    = "-"        // Igore for validater
 	RequiredKey   = "required" // required key for not empty value
+
+	DefaultMaxDepth = 64 // Max depth of nested structs
 )
 
 var (
 	// Init by this pkg. no need rwlock
 	validatorsMap = map[string]ValidaterFunc{
 		RequiredKey: requiredChecker,
-		"email":     emailChecker,
-		"url":       urlChecker,
+		EmailKey:    emailChecker,
+		URLKey:      urlChecker,
+
+		IPKey:        ipChecker,
+		IPv4Key:      ipv4Checker,
+		IPv6Key:      ipv6Checker,
+		CIDRKey:      cidrChecker,
+		PrivateIPKey: privateIPChecker,
+		MACKey:       macChecker,
+		HostPortKey:  hostPortChecker,
+		PortKey:      portChecker,
+		HostnameKey:  hostnameChecker,
+		FQDNKey:      fqdnChecker,
+
+		UUIDKey:        uuidChecker,
+		UUID4Key:       uuid4Checker,
+		UUID7Key:       uuid7Checker,
+		ULIDKey:        ulidChecker,
+		KSUIDKey:       ksuidChecker,
+		SemverKey:      semverChecker,
+		SemverRangeKey: semverRangeChecker,
+
+		CreditCardKey: creditCardChecker,
+		IBANKey:       ibanChecker,
+		ISBN10Key:     isbn10Checker,
+		ISBN13Key:     isbn13Checker,
+		EAN13Key:      ean13Checker,
+		ISSNKey:       issnChecker,
+
+		CurrencyKey: currencyChecker,
+		LanguageKey: languageChecker,
+		TimezoneKey: timezoneChecker,
+
+		E164Key: e164Checker,
+
+		URIKey:         uriChecker,
+		HTTPURLKey:     httpURLChecker,
+		RequireHostKey: requireHostChecker,
+		NoUserinfoKey:  noUserinfoChecker,
+		NoIPHostKey:    noIPHostChecker,
 	}
 
 	// Using rwlock avoid race
@@ -152,7 +207,7 @@ func (cvm *CustomValidators) AddValidater(name string, validater ValidaterFunc)
 	}
 
 	// check name conflict
-	if validatorsMap[name] != nil {
+	if isBuiltinRule(name) {
 		return ErrValidaterExists
 	}
 
@@ -175,6 +230,26 @@ func (cvm *CustomValidators) findValidater(name string) (v ValidaterFunc, ok boo
 // Validation err list
 type Validation struct {
 	Errors []*Error
+
+	// Engine state, keep after Reset
+	mu            sync.Mutex // guard plans and varPlans
+	plans         map[reflect.Type]*structPlan
+	varPlans      map[string]*fieldPlan
+	patternsOnce  sync.Once
+	patterns      *patternCache
+	clock         func() time.Time
+	resolver      Resolver
+	lookupTimeout time.Duration
+	dnsOnce       sync.Once
+	dns           *dnsCache
+	embeddedPath  bool
+	nestedPath    bool
+	maxDepth      int
+
+	// State of one Validate call
+	depth   int
+	visited map[visitKey]bool
+	prefix  string // path of nested struct if SetNestedPath
 }
 
 // NewValidation create a new validation
@@ -220,11 +295,13 @@ func (mv *Validation) Validate(obj interface{}) bool {
 
 	v := reflect.ValueOf(obj)
 	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			debug("obj is nil ptr")
+			return true
+		}
 		v = v.Elem()
 	}
 
-	t := v.Type()
-
 	// Here only accept structs
 	if v.Kind() != reflect.Struct {
 		err := &ErrOnlyStrcut{Type: v.Type()}
@@ -232,40 +309,162 @@ func (mv *Validation) Validate(obj interface{}) bool {
 		return false
 	}
 
+	// Top level call, new object graph
+	if mv.depth == 0 {
+		mv.visited = make(map[visitKey]bool)
+	}
+
+	mv.validateStruct(v)
+
+	if mv.HasError() {
+		return false
+	}
+
+	return true
+}
+
+// Call struct hooks and check fields of struct value
+func (mv *Validation) validateStruct(v reflect.Value) {
+	t := v.Type()
+
+	// Check every object once, recursive graph may have cycle
+	if v.CanAddr() {
+		key := visitKey{ptr: v.UnsafeAddr(), typ: t}
+		if mv.visited[key] {
+			debugf("Skip visited struct [%s]", t.Name())
+			return
+		}
+
+		if mv.visited == nil {
+			mv.visited = make(map[visitKey]bool)
+		}
+		mv.visited[key] = true
+	}
+
+	maxDepth := mv.depthLimit()
+	if mv.depth >= maxDepth {
+		mv.addError("Object", v.Interface(), &ErrMaxDepth{Depth: maxDepth, Type: t})
+		return
+	}
+
+	mv.depth++
+	defer func() { mv.depth-- }()
+
 	debugf("Check struct [%s]", t.Name())
 
+	obj := hookReceiver(v)
+
 	objvk, ok := obj.(Validater)
 	if ok {
 		err := objvk.Validater()
 		if err != nil {
-			mv.addError("Object", obj, err)
+			mv.addError("Object", v.Interface(), err)
 		}
 	}
 
-	for i := 0; i < v.NumField(); i++ {
-		tf := t.Field(i) // type field
-		vf := v.Field(i) // vaule field
+	if objsv, ok := obj.(StructValidater); ok {
+		objsv.ValidateStruct(&structReporter{mv: mv, v: v})
+	}
 
-		// Skip Anonymous and private field
-		if !tf.Anonymous && len(tf.PkgPath) > 0 {
+	for _, fp := range mv.planFor(t).fields {
+		vf, ok := fieldByIndex(v, fp.index)
+
+		// Promoted field of nil embedded ptr
+		if !ok {
 			continue
 		}
 
-		fns := mv.getValidFuns(tf, ValidTag)
-
-		// Already skip ValidIgnor flag, such as "-"
-		if len(fns) == 0 {
+		if fp.embedded {
+			mv.checkEmbedded(vf, fp)
 			continue
 		}
 
-		mv.typeCheck(vf, tf, v, false)
+		mv.typeCheck(vf, fp, v, false)
 	}
+}
 
-	if mv.HasError() {
-		return false
+// visitKey struct checked in one Validate, embedded struct has same address
+// as outer struct, so type is needed
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// SetMaxDepth set max depth of nested structs, default is DefaultMaxDepth
+func (mv *Validation) SetMaxDepth(depth int) {
+	mv.maxDepth = depth
+}
+
+// Return max depth, DefaultMaxDepth if not set
+func (mv *Validation) depthLimit() int {
+	if mv.maxDepth <= 0 {
+		return DefaultMaxDepth
 	}
 
-	return true
+	return mv.maxDepth
+}
+
+// Like reflect.Value.FieldByIndex, but return false for nil embedded ptr
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+// Embedded struct fields are promoted, only check required for it. Its hooks
+// are not called here, they are promoted to outer struct as Go methods, so
+// hidden by hook of outer struct, and lost if ambiguous.
+func (mv *Validation) checkEmbedded(v reflect.Value, fp *fieldPlan) {
+	if !fp.required || !v.CanInterface() {
+		return
+	}
+
+	if err := mv.checkRequire(v, fp.field); err != nil {
+		mv.addError(mv.fieldName(fp), v.Interface(), err)
+	}
+}
+
+// Return error name of field, with embedded type names if SetEmbeddedPath
+func (mv *Validation) fieldName(fp *fieldPlan) string {
+	if mv.embeddedPath {
+		return fp.path
+	}
+
+	return fp.field.Name
+}
+
+// SetEmbeddedPath report promoted fields with embedded type names, "Base.ID",
+// default is "ID" like Go field promotion.
+func (mv *Validation) SetEmbeddedPath(on bool) {
+	mv.embeddedPath = on
+}
+
+// SetNestedPath report fields of nested structs with names of outer fields,
+// "Address.City", default is "City". Elements of slice and map are under name
+// of the field, "Homes.City", "Object" error of nested struct is on "Address".
+func (mv *Validation) SetNestedPath(on bool) {
+	mv.nestedPath = on
+}
+
+// Return pointer to struct value, so hooks with both value and pointer
+// receivers can be found. Not addressable value is copied.
+func hookReceiver(v reflect.Value) interface{} {
+	if v.CanAddr() {
+		return v.Addr().Interface()
+	}
+
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+
+	return p.Interface()
 }
 
 func (mv *Validation) checkRequire(v reflect.Value, t reflect.StructField) error {
@@ -278,25 +477,14 @@ func (mv *Validation) typeCheckPtr(v reflect.Value, t reflect.StructField, o ref
 }
 
 // Valid struct field type, if typeCheck is ptr, wo just check ptr for required, not for element
-func (mv *Validation) typeCheck(v reflect.Value, t reflect.StructField, o reflect.Value, ignoreRequired bool) {
-	fns := mv.getValidFuns(t, ValidTag)
-
-	// skip
-	if len(fns) == 0 {
-		return
-	}
-
-	if ignoreRequired {
-		delete(fns, RequiredKey)
-	}
+func (mv *Validation) typeCheck(v reflect.Value, fp *fieldPlan, o reflect.Value, ignoreRequired bool) {
+	t := fp.field
 
 	// First check all field for required
-	if fns[RequiredKey] != nil {
+	if fp.required && !ignoreRequired {
 		if err := mv.checkRequire(v, t); err != nil {
-			mv.addError(t.Name, v.Interface(), err)
+			mv.addError(mv.fieldName(fp), v.Interface(), err)
 		}
-
-		delete(fns, RequiredKey)
 	}
 
 	switch v.Kind() {
@@ -306,66 +494,96 @@ func (mv *Validation) typeCheck(v reflect.Value, t reflect.StructField, o reflec
 		reflect.Float32, reflect.Float64,
 		reflect.String:
 
-		debugf("\tCheck field [%s]", t.Name)
-
-		for fname := range fns {
-			debugf("CheckerName: [%s]", fname)
-
-			// find custom map and pkg map
-			var vck ValidaterFunc
-			var find bool
-
-			if vck, find = customValidatorsMap.findValidater(fname); !find {
-				vck = validatorsMap[fname]
-			}
-
-			if vck == nil {
-				err := fmt.Errorf("can't find checker for [%s]", fname)
-				mv.addError(t.Name, v.Interface(), err)
-				debugf("can't find checker for [%s]", fname)
-				continue
-			}
-
-			err := vck(v.Interface())
-			if err != nil {
-				mv.addError(t.Name, v.Interface(), err)
-			}
-		}
+		mv.checkValue(v, fp, o)
 
 	case reflect.Slice, reflect.Array:
 		for i := 0; i < v.Len(); i++ {
-			if v.Index(i).Kind() != reflect.Struct {
-				mv.typeCheck(v.Index(i), t, o, false)
-			} else {
-				mv.Validate(v.Index(i).Interface())
-			}
+			mv.typeCheckElem(v.Index(i), fp, o)
+		}
+
+	case reflect.Map:
+		// Check map values, keys sorted for stable error order
+		for _, key := range sortedMapKeys(v) {
+			mv.typeCheckElem(v.MapIndex(key), fp, o)
 		}
 
 	case reflect.Interface:
-		// If the value is an interface then encode its element
+		// If the value is an interface then check its dynamic value,
+		// required already checked on interface
 		if !v.IsNil() {
-			mv.Validate(v.Elem())
+			mv.typeCheck(v.Elem(), fp, o, true)
 		}
 
 	case reflect.Ptr:
 		// only check
 		// If the value is a pointer then check its element
 		if !v.IsNil() {
-			mv.typeCheck(v.Elem(), t, o, true)
+			mv.typeCheck(v.Elem(), fp, o, true)
 		}
 
 	case reflect.Struct:
-		mv.Validate(v.Interface())
+		if isValueStruct(v.Type()) {
+			mv.checkValue(v, fp, o)
+			break
+		}
+
+		mv.validateNested(v, fp)
 
-	// case reflect.Map: // don't support map now
 	default:
 		err := fmt.Errorf("UnspportType %s", v.Type())
-		mv.addError(t.Name, v.Interface(), err)
+		mv.addError(mv.fieldName(fp), v.Interface(), err)
 	}
 
 	return
 }
 
+// Check element of slice, array or map, struct element is validated by its own tags
+func (mv *Validation) typeCheckElem(v reflect.Value, fp *fieldPlan, o reflect.Value) {
+	if v.Kind() != reflect.Struct || isValueStruct(v.Type()) {
+		mv.typeCheck(v, fp, o, false)
+	} else {
+		mv.validateNested(v, fp)
+	}
+}
+
+// Validate struct value of field fp, its errors are under path of fp if
+// SetNestedPath
+func (mv *Validation) validateNested(v reflect.Value, fp *fieldPlan) {
+	if mv.nestedPath {
+		outer := mv.prefix
+		mv.prefix = joinPath(outer, mv.fieldName(fp))
+		defer func() { mv.prefix = outer }()
+	}
+
+	mv.validateStruct(v)
+}
+
+// Return map keys sorted by printed value
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	return keys
+}
+
+// Run field rules on single value, o is struct of field for cross field rules
+func (mv *Validation) checkValue(v reflect.Value, fp *fieldPlan, o reflect.Value) {
+	t := fp.field
+
+	debugf("\tCheck field [%s]", t.Name)
+
+	for _, r := range fp.rules {
+		debugf("CheckerName: [%s]", r.name)
+
+		err := mv.checkRule(r, v.Interface(), o)
+		if err != nil {
+			mv.addError(mv.fieldName(fp), v.Interface(), err)
+		}
+	}
+}
+
 // Clear error
 func (mv *Validation) clear() {
 	mv.Errors = nil
@@ -373,23 +591,28 @@ func (mv *Validation) clear() {
 
 // Apend error to validtion
 func (mv *Validation) addError(key string, v interface{}, err error) {
+	if mv.prefix != "" {
+		if key == "Object" {
+			key = mv.prefix
+		} else {
+			key = joinPath(mv.prefix, key)
+		}
+	}
+
 	errtmp := &Error{FieldName: key, Value: v, Err: err}
 	mv.Errors = append(mv.Errors, errtmp)
 }
 
 // Return fun names and params
 func (mv *Validation) getValidFuns(tf reflect.StructField, tag string) map[string]interface{} {
-	out := make(map[string]interface{})
-
-	opt, ok := tf.Tag.Lookup(tag)
-	if !ok || len(opt) == 0 || opt == ValidIgnor {
+	trs := parseTag(tf.Tag.Get(tag))
+	if len(trs) == 0 {
 		return nil
 	}
 
-	for _, value := range strings.Split(opt, FuncSeparator) {
-		// omit func has params for now
-		value = strings.TrimSpace(value)
-		out[value] = struct{}{}
+	out := make(map[string]interface{})
+	for _, tr := range trs {
+		out[tr.name] = tr.param
 	}
 
 	return out
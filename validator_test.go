@@ -1,8 +1,10 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -220,3 +222,51 @@ func TestCustomValidaterConflict(t *testing.T) {
 		t.Errorf("AddValidater should failed [ErrValidaterExists]. but got %s\n", err.Error())
 	}
 }
+
+type PathStreet struct {
+	Name string `valid:"required"`
+}
+
+type PathAddress struct {
+	Street PathStreet `valid:"required"`
+	City   string     `valid:"required"`
+}
+
+func (a PathAddress) Validater() error {
+	return errors.New("address hook")
+}
+
+type PathUser struct {
+	Name    string       `valid:"required"`
+	Address *PathAddress `valid:"required"`
+	Homes   []PathStreet `valid:"required"`
+}
+
+func TestSetNestedPath(t *testing.T) {
+	u := &PathUser{Address: &PathAddress{Street: PathStreet{Name: "x"}}, Homes: []PathStreet{{}}}
+
+	validor := NewValidation()
+	validor.Validate(u)
+
+	var got []string
+	for _, err := range validor.Errs() {
+		got = append(got, err.FieldName)
+	}
+
+	if want := "Name,Object,City,Name"; strings.Join(got, ",") != want {
+		t.Errorf("should got errors on %s, but got %s", want, strings.Join(got, ","))
+	}
+
+	validor.Reset()
+	validor.SetNestedPath(true)
+	validor.Validate(u)
+
+	got = nil
+	for _, err := range validor.Errs() {
+		got = append(got, err.FieldName)
+	}
+
+	if want := "Name,Address,Address.City,Homes.Name"; strings.Join(got, ",") != want {
+		t.Errorf("should got errors on %s, but got %s", want, strings.Join(got, ","))
+	}
+}
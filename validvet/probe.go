@@ -0,0 +1,93 @@
+package validvet
+
+import (
+	"errors"
+	"go/types"
+	"reflect"
+	"time"
+
+	"github.com/DavadDi/validation"
+)
+
+// Stand for named types of checked package, checkers see them same as the
+// field, "type Email string" is not string for email
+type (
+	namedBool    bool
+	namedInt     int
+	namedInt8    int8
+	namedInt16   int16
+	namedInt32   int32
+	namedInt64   int64
+	namedUint    uint
+	namedUint8   uint8
+	namedUint16  uint16
+	namedUint32  uint32
+	namedUint64  uint64
+	namedUintptr uintptr
+	namedFloat32 float32
+	namedFloat64 float64
+	namedString  string
+)
+
+// Reflect types of basic kinds, plain and named
+var basicTypes = map[types.BasicKind][2]reflect.Type{
+	types.Bool:    {reflect.TypeOf(false), reflect.TypeOf(namedBool(false))},
+	types.Int:     {reflect.TypeOf(int(0)), reflect.TypeOf(namedInt(0))},
+	types.Int8:    {reflect.TypeOf(int8(0)), reflect.TypeOf(namedInt8(0))},
+	types.Int16:   {reflect.TypeOf(int16(0)), reflect.TypeOf(namedInt16(0))},
+	types.Int32:   {reflect.TypeOf(int32(0)), reflect.TypeOf(namedInt32(0))},
+	types.Int64:   {reflect.TypeOf(int64(0)), reflect.TypeOf(namedInt64(0))},
+	types.Uint:    {reflect.TypeOf(uint(0)), reflect.TypeOf(namedUint(0))},
+	types.Uint8:   {reflect.TypeOf(uint8(0)), reflect.TypeOf(namedUint8(0))},
+	types.Uint16:  {reflect.TypeOf(uint16(0)), reflect.TypeOf(namedUint16(0))},
+	types.Uint32:  {reflect.TypeOf(uint32(0)), reflect.TypeOf(namedUint32(0))},
+	types.Uint64:  {reflect.TypeOf(uint64(0)), reflect.TypeOf(namedUint64(0))},
+	types.Uintptr: {reflect.TypeOf(uintptr(0)), reflect.TypeOf(namedUintptr(0))},
+	types.Float32: {reflect.TypeOf(float32(0)), reflect.TypeOf(namedFloat32(0))},
+	types.Float64: {reflect.TypeOf(float64(0)), reflect.TypeOf(namedFloat64(0))},
+	types.String:  {reflect.TypeOf(""), reflect.TypeOf(namedString(""))},
+}
+
+// Return reflect type checkers see for t, false if it can't be made
+func reflectType(t types.Type) (reflect.Type, bool) {
+	t = types.Unalias(t)
+
+	switch types.TypeString(t, nil) {
+	case "time.Time":
+		return reflect.TypeOf(time.Time{}), true
+	case "time.Duration":
+		return reflect.TypeOf(time.Duration(0)), true
+	}
+
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return nil, false
+	}
+
+	rts, ok := basicTypes[b.Kind()]
+	if !ok {
+		return nil, false
+	}
+
+	if _, named := t.(*types.Named); named {
+		return rts[1], true
+	}
+
+	return rts[0], true
+}
+
+// Run checker of rule on zero value like Compile, return error if the rule
+// never accept type t
+func probe(tr validation.TagRule, t types.Type) *validation.ErrWrongExpectType {
+	rt, ok := reflectType(t)
+	if !ok {
+		return nil
+	}
+
+	var wrong *validation.ErrWrongExpectType
+	if errors.As(validation.ProbeRule(tr.Name, tr.Param, rt), &wrong) {
+		return wrong
+	}
+
+	return nil
+}
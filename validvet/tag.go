@@ -0,0 +1,60 @@
+package validvet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Max edit distance for "did you mean"
+const maxSuggestDistance = 2
+
+// Return ", did you mean [x]?" for closest candidate, empty if none is close
+func suggest(name string, candidates []string) string {
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+
+	best, bestDist := "", maxSuggestDistance+1
+	for _, s := range sorted {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(s)); d < bestDist && d < len(s) {
+			best, bestDist = s, d
+		}
+	}
+
+	if best == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(", did you mean [%s]?", best)
+}
+
+// Optimal string alignment distance, swap of adjacent chars is one edit,
+// "requried" is 1 from "required"
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(ra)][len(rb)]
+}
@@ -0,0 +1,51 @@
+package a
+
+import (
+	"time"
+
+	"github.com/DavadDi/validation"
+)
+
+func init() {
+	validation.AddValidater("even", func(v interface{}) error { return nil })
+}
+
+type Email string
+
+type Address struct {
+	City string `valid:"required;max_len=8"`
+}
+
+type User struct {
+	Name     string          `valid:"requried;min_len=2"` // want `unknown rule \[requried\], did you mean \[required\]\?`
+	Mail     string          `valid:"emial"`              // want `unknown rule \[emial\], did you mean \[email\]\?`
+	Nick     string          `valid:"foobarbaz"`          // want `unknown rule \[foobarbaz\]$`
+	Age      int             `valid:"email"`              // want `rule \[email\] need string, but field \[Age\] is int`
+	Alias    Email           `valid:"email"`              // want `rule \[email\] need string, but field \[Alias\] is Email`
+	Bio      string          `valid:"min_len=x"`          // want `bad param of \[min_len\]: min_len need non-negative int, but got \[x\]`
+	Role     string          `valid:"oneof"`              // want `bad param of \[oneof\]: oneof need value list`
+	ID       string          `valid:"uuid=4"`             // want `bad param of \[uuid\]: checker \[uuid\] don't accept param \[4\]`
+	Port     int             `valid:"port"`
+	Small    uint8           `valid:"port"`
+	Code     Email           `valid:"port"` // want `rule \[port\] need string or int, but field \[Code\] is Email`
+	Level    int             `valid:"oneof=1,2,3;even"`
+	Tags     []string        `valid:"regex=^[a-z]+$"`
+	Hosts    map[string]*int `valid:"ip"` // want `rule \[ip\] need string, but field \[Hosts\] is int`
+	Born     *time.Time      `valid:"past"`
+	Timeout  time.Duration   `valid:"min_duration=1s"`
+	Created  string          `valid:"past"`           // want `rule \[past\] need time.Time, but field \[Created\] is string`
+	Home     Address         `valid:"required;email"` // want `rule \[email\] of struct field \[Home\] is never checked, struct is validated by its own tags`
+	Extra    interface{}     `valid:"email"`
+	Password string          `valid:"required"`
+	Confirm  string          `valid:"eqfield=Pasword"` // want `can't find field \[Pasword\] for \[eqfield\], did you mean \[Password\]\?`
+	Start    time.Time
+	End      time.Time         `valid:"gtfield=Start"`
+	Limit    int               `valid:"ltfield=Name"` // want `rule \[ltfield\] need ordered values of same kind, but field \[Limit\] is int and \[Name\] is string`
+	Other    string            `valid:"nefield"`      // want `rule \[nefield\] need other field name`
+	Skip     string            `valid:"-"`
+	Labels   map[string]string `valid:""`
+	token    string            `valid:"required"` // want `valid tag of unexported field \[token\] is never checked`
+	Anon     struct {
+		X int `valid:"fqdn"` // want `rule \[fqdn\] need string, but field \[X\] is int`
+	}
+}
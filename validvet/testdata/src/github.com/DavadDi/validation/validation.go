@@ -0,0 +1,10 @@
+// Package validation stub for validvet tests
+package validation
+
+// ValidaterFunc check value
+type ValidaterFunc func(v interface{}) error
+
+// AddValidater add user define Validater
+func AddValidater(name string, validater ValidaterFunc) error {
+	return nil
+}
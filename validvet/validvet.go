@@ -0,0 +1,317 @@
+// Package validvet define an analyzer for valid tags. Mistakes in tags are
+// only found by Validate at runtime, validvet report them at build time:
+//
+//	type User struct {
+//		Name  string `valid:"requried"`  // unknown rule [requried], did you mean [required]?
+//		Age   int    `valid:"email"`     // rule [email] need string, but field [Age] is int
+//		Nick  string `valid:"min_len=x"` // bad param of [min_len]: ...
+//		token string `valid:"required"`  // valid tag of unexported field [token] is never checked
+//	}
+//
+// Rules added by AddValidater in the same package are known, rules added in
+// other packages can be passed by -rules flag.
+package validvet
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/DavadDi/validation"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const validationPath = "github.com/DavadDi/validation"
+
+// Analyzer report unknown rules, bad params, rules on fields of wrong type
+// and valid tags never checked
+var Analyzer = &analysis.Analyzer{
+	Name:     "validvet",
+	Doc:      "check valid tags of github.com/DavadDi/validation",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// Comma separated rules added by AddValidater in other packages
+var customRules string
+
+func init() {
+	Analyzer.Flags.StringVar(&customRules, "rules", "", "comma separated rule names added by AddValidater in other packages")
+}
+
+// Cross field rules need ordered values
+var orderRules = map[string]bool{
+	validation.GtFieldKey:  true,
+	validation.GteFieldKey: true,
+	validation.LtFieldKey:  true,
+	validation.LteFieldKey: true,
+}
+
+// checker check fields of one struct
+type checker struct {
+	pass    *analysis.Pass
+	builtin map[string]bool
+	known   map[string]bool // builtin and added by AddValidater
+	names   []string        // known names for suggestion
+	st      *types.Struct
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	c := &checker{
+		pass:    pass,
+		builtin: make(map[string]bool),
+		known:   make(map[string]bool),
+	}
+
+	for _, name := range validation.RuleNames() {
+		c.builtin[name] = true
+		c.known[name] = true
+	}
+
+	for _, name := range strings.Split(customRules, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			c.known[name] = true
+		}
+	}
+
+	// Rules added in this package
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		if name, ok := addedRule(pass, n.(*ast.CallExpr)); ok {
+			c.known[name] = true
+		}
+	})
+
+	for name := range c.known {
+		c.names = append(c.names, name)
+	}
+
+	ins.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		node := n.(*ast.StructType)
+
+		st, ok := pass.TypesInfo.TypeOf(node).(*types.Struct)
+		if !ok {
+			return
+		}
+
+		c.st = st
+		for _, f := range node.Fields.List {
+			c.field(f)
+		}
+	})
+
+	return nil, nil
+}
+
+// Return name of AddValidater("name", fn) call with constant name
+func addedRule(pass *analysis.Pass, call *ast.CallExpr) (string, bool) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Name() != "AddValidater" || fn.Pkg() == nil || fn.Pkg().Path() != validationPath || len(call.Args) == 0 {
+		return "", false
+	}
+
+	tv := pass.TypesInfo.Types[call.Args[0]]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(tv.Value), true
+}
+
+// Check valid tag of field, a field can have many names
+func (c *checker) field(f *ast.Field) {
+	if f.Tag == nil {
+		return
+	}
+
+	str, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return
+	}
+
+	tag, ok := reflect.StructTag(str).Lookup(validation.ValidTag)
+	if !ok {
+		return
+	}
+
+	trs := validation.ParseTag(tag)
+	if len(trs) == 0 {
+		return
+	}
+
+	// Embedded field, fields of struct are promoted
+	if len(f.Names) == 0 {
+		c.rules(f, typeName(c.pass.TypesInfo.TypeOf(f.Type)), c.pass.TypesInfo.TypeOf(f.Type), trs)
+		return
+	}
+
+	for _, ident := range f.Names {
+		if !ident.IsExported() {
+			c.pass.Reportf(f.Tag.Pos(), "valid tag of unexported field [%s] is never checked", ident.Name)
+			continue
+		}
+
+		c.rules(f, ident.Name, c.pass.TypesInfo.TypeOf(f.Type), trs)
+	}
+}
+
+// Return name of embedded type, "Base" for *pkg.Base
+func typeName(t types.Type) string {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	if n, ok := t.(*types.Named); ok {
+		return n.Obj().Name()
+	}
+
+	return types.TypeString(t, nil)
+}
+
+// Check rules of field, type t is the field type
+func (c *checker) rules(f *ast.Field, name string, t types.Type, trs []validation.TagRule) {
+	elem := valueType(t)
+	if !isSupported(elem) {
+		c.pass.Reportf(f.Tag.Pos(), "field [%s] has unsupported type %s, check always fails", name, c.typeString(t))
+		return
+	}
+
+	for _, tr := range trs {
+		if tr.Name == validation.RequiredKey {
+			continue
+		}
+
+		if !c.known[tr.Name] {
+			c.pass.Reportf(f.Tag.Pos(), "unknown rule [%s]%s", tr.Name, suggest(tr.Name, c.names))
+			continue
+		}
+
+		if c.builtin[tr.Name] {
+			if err := validation.CheckRule(tr.Name, tr.Param); err != nil {
+				c.pass.Reportf(f.Tag.Pos(), "bad param of [%s]: %s", tr.Name, err)
+				continue
+			}
+		}
+
+		// Dynamic type is only known at runtime
+		if _, ok := elem.Underlying().(*types.Interface); ok {
+			continue
+		}
+
+		if isStruct(elem) {
+			c.pass.Reportf(f.Tag.Pos(), "rule [%s] of struct field [%s] is never checked, struct is validated by its own tags", tr.Name, name)
+			continue
+		}
+
+		if validation.IsCrossRule(tr.Name) {
+			c.cross(f, name, elem, tr)
+			continue
+		}
+
+		if wrong := probe(tr, elem); wrong != nil {
+			c.pass.Reportf(f.Tag.Pos(), "rule [%s] need %s, but field [%s] is %s", tr.Name, wrong.ExpectType, name, c.typeString(elem))
+		}
+	}
+}
+
+// Check other field of cross field rule
+func (c *checker) cross(f *ast.Field, name string, t types.Type, tr validation.TagRule) {
+	if tr.Param == "" {
+		c.pass.Reportf(f.Tag.Pos(), "rule [%s] need other field name", tr.Name)
+		return
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(c.st, false, c.pass.Pkg, tr.Param)
+	other, ok := obj.(*types.Var)
+	if !ok || !other.IsField() || !other.Exported() {
+		var fields []string
+		for i := 0; i < c.st.NumFields(); i++ {
+			if c.st.Field(i).Exported() {
+				fields = append(fields, c.st.Field(i).Name())
+			}
+		}
+
+		c.pass.Reportf(f.Tag.Pos(), "can't find field [%s] for [%s]%s", tr.Param, tr.Name, suggest(tr.Param, fields))
+		return
+	}
+
+	if !orderRules[tr.Name] {
+		return
+	}
+
+	ot := other.Type()
+	if p, ok := ot.Underlying().(*types.Pointer); ok {
+		ot = p.Elem()
+	}
+
+	if class := orderClass(t); class == "" || class != orderClass(ot) {
+		c.pass.Reportf(f.Tag.Pos(), "rule [%s] need ordered values of same kind, but field [%s] is %s and [%s] is %s",
+			tr.Name, name, c.typeString(t), tr.Param, c.typeString(ot))
+	}
+}
+
+func (c *checker) typeString(t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(c.pass.Pkg))
+}
+
+// Return type checked by rules, elements of pointer, slice, array and map
+func valueType(t types.Type) types.Type {
+	for {
+		switch u := t.Underlying().(type) {
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice:
+			t = u.Elem()
+		case *types.Array:
+			t = u.Elem()
+		case *types.Map:
+			t = u.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// Chan, func and complex fields always fail with UnspportType
+func isSupported(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Chan, *types.Signature:
+		return false
+	case *types.Basic:
+		return u.Info()&types.IsComplex == 0 && u.Kind() != types.UnsafePointer
+	}
+
+	return true
+}
+
+// Struct walked for fields, time.Time is checked as value
+func isStruct(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Struct)
+	return ok && types.TypeString(t, nil) != "time.Time"
+}
+
+// Return "number", "string" or "time", empty if can't be ordered
+func orderClass(t types.Type) string {
+	if types.TypeString(t, nil) == "time.Time" {
+		return "time"
+	}
+
+	b, ok := t.Underlying().(*types.Basic)
+	switch {
+	case !ok:
+		return ""
+	case b.Info()&types.IsString != 0:
+		return "string"
+	case b.Info()&types.IsNumeric != 0 && b.Info()&types.IsComplex == 0:
+		return "number"
+	}
+
+	return ""
+}
@@ -0,0 +1,33 @@
+package validvet
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
+
+func TestSuggest(t *testing.T) {
+	names := []string{"required", "email", "email_mx", "ip", "ipv4"}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"requried", ", did you mean [required]?"},
+		{"emial", ", did you mean [email]?"},
+		{"Email", ", did you mean [email]?"},
+		{"ipv5", ", did you mean [ipv4]?"},
+		{"x", ""},
+		{"unknown", ""},
+	}
+
+	for _, tt := range tests {
+		if got := suggest(tt.name, names); got != tt.want {
+			t.Errorf("suggest(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"reflect"
+)
+
+// VarName field name of errors from ValidateVar and ValidateVarWithValue
+const VarName = "Var"
+
+// ValidateVar check single value by tag, same as field tag of struct.
+// Rules of slice, array and map are checked on every element, map values
+// in order of keys.
+//
+//	validor.ValidateVar("dave@do1618.com", "required;email")
+//	validor.ValidateVar([]string{"a", "b"}, "regex=^[a-z]+$")
+func (mv *Validation) ValidateVar(value interface{}, tag string) bool {
+	return mv.validateVar(value, reflect.Value{}, tag)
+}
+
+// ValidateVarWithValue check value against other value by cross field tag,
+// param of rule is empty.
+//
+//	validor.ValidateVarWithValue(password, confirm, "eqfield")
+//	validor.ValidateVarWithValue(end, start, "required;gtfield")
+func (mv *Validation) ValidateVarWithValue(value, other interface{}, tag string) bool {
+	return mv.validateVar(value, reflect.ValueOf(other), tag)
+}
+
+func (mv *Validation) validateVar(value interface{}, o reflect.Value, tag string) bool {
+	fp := mv.varPlan(tag)
+
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		// nil value, only required can be checked
+		if fp.required {
+			mv.addError(VarName, value, ErrRequired)
+		}
+		return !mv.HasError()
+	}
+
+	// Top level call, new object graph
+	if mv.depth == 0 {
+		mv.visited = make(map[visitKey]bool)
+	}
+
+	mv.typeCheck(v, fp, o, false)
+
+	return !mv.HasError()
+}
+
+// Return plan of tag, compiled once and cached
+func (mv *Validation) varPlan(tag string) *fieldPlan {
+	mv.mu.Lock()
+	defer mv.mu.Unlock()
+
+	if mv.varPlans == nil {
+		mv.varPlans = make(map[string]*fieldPlan)
+	}
+
+	if fp, ok := mv.varPlans[tag]; ok {
+		return fp
+	}
+
+	tf := reflect.StructField{Name: VarName}
+
+	// Empty tag still walk into structs and their tags
+	fp := mv.compileField(tf, tag)
+	if fp == nil {
+		fp = &fieldPlan{field: tf}
+	}
+	fp.path = VarName
+	mv.varPlans[tag] = fp
+
+	return fp
+}
@@ -0,0 +1,91 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateVar(t *testing.T) {
+	var nilPtr *string
+
+	tests := []struct {
+		value interface{}
+		tag   string
+		ok    bool
+	}{
+		{"dave@do1618.com", "required;email", true},
+		{"dave", "required;email", false},
+		{"", "required;email", false},
+		{nil, "required", false},
+		{nil, "email", true},
+		{nilPtr, "required", false},
+		{5, "required", true},
+		{"abc", "", true},
+		{[]string{"abc", "xyz"}, "regex=^[a-z]+$", true},
+		{[]string{"abc", "XYZ"}, "regex=^[a-z]+$", false},
+		{[]string(nil), "required", false},
+		{map[string]string{"a": "http://a.com", "b": "ftp://b.com"}, "url=http,https", false},
+		{map[string]string{"a": "http://a.com"}, "url=http,https", true},
+		{map[string]string{"a": "dave@do1618.com"}, "email", true},
+		{map[string]string{"a": "dave@do1618.com", "b": "x"}, "email", false},
+		{time.Minute, "min_duration=1s", true},
+		{"CN", "unknown_rule", false},
+	}
+
+	validor := NewValidation()
+	for _, test := range tests {
+		validor.Reset()
+		if ok := validor.ValidateVar(test.value, test.tag); ok != test.ok {
+			t.Errorf("ValidateVar(%#v, %q) should be %v, but got %v: %s", test.value, test.tag, test.ok, ok, validor.ErrMsg())
+		}
+	}
+
+	validor.Reset()
+	validor.ValidateVar("dave", "email")
+	if len(validor.Errs()) != 1 || validor.Errs()[0].FieldName != VarName || !errors.Is(validor.Errs()[0].Err, ErrBadEmailFormat) {
+		t.Errorf("should got email error on %s, but got %s", VarName, validor.ErrMsg())
+	}
+
+	// struct value checked by its own tags
+	validor.Reset()
+	if validor.ValidateVar(TreeNode{}, "") {
+		t.Errorf("ValidateVar should failed for struct without name")
+	}
+}
+
+func TestValidateVarWithValue(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		value interface{}
+		other interface{}
+		tag   string
+		ok    bool
+	}{
+		{"secret", "secret", "eqfield", true},
+		{"secret", "Secret", "eqfield", false},
+		{"new", "old", "nefield", true},
+		{10, 5, "gtfield", true},
+		{5, 5, "gtfield", false},
+		{5, 5, "gtefield", true},
+		{uint8(3), 4.5, "ltfield", true},
+		{now, now.Add(time.Hour), "ltfield", true},
+		{now, now.Add(-time.Hour), "ltefield", false},
+		{time.Second, time.Minute, "ltfield", true},
+		{"b", "a", "gtfield", true},
+		{"b", 1, "gtfield", false},
+		{[]int{1, 2}, 3, "ltfield", true},
+		{[]int{1, 5}, 3, "ltfield", false},
+		{10, 5, "required;gtfield", true},
+	}
+
+	validor := NewValidation()
+	for _, test := range tests {
+		validor.Reset()
+		if ok := validor.ValidateVarWithValue(test.value, test.other, test.tag); ok != test.ok {
+			t.Errorf("ValidateVarWithValue(%#v, %#v, %q) should be %v, but got %v: %s",
+				test.value, test.other, test.tag, test.ok, ok, validor.ErrMsg())
+		}
+	}
+}
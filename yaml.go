@@ -0,0 +1,146 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Plain yaml scalar, others are written as JSON strings, which are valid
+// yaml double-quoted strings
+var rxYAMLPlain = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_./-]*$`)
+
+// Plain scalars read as bool or null by yaml 1.1
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "null": true, "yes": true, "no": true,
+	"on": true, "off": true, "y": true, "n": true,
+}
+
+// yamlNode JSON value with key order kept
+type yamlNode struct {
+	keys   []string    // keys of object
+	items  []*yamlNode // values of object or items of array
+	array  bool
+	scalar string // yaml text of scalar, empty for object and array
+}
+
+// Convert JSON to block style yaml, key order is kept
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	n, err := readYAMLNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	for _, line := range n.lines(0) {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+
+	return b.Bytes(), nil
+}
+
+func readYAMLNode(dec *json.Decoder) (*yamlNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		n := &yamlNode{array: t == '['}
+		for dec.More() {
+			if !n.array {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, key.(string))
+			}
+
+			item, err := readYAMLNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.items = append(n.items, item)
+		}
+
+		// Closing delim
+		if _, err := dec.Token(); err != nil && err != io.EOF {
+			return nil, err
+		}
+		return n, nil
+
+	case string:
+		return &yamlNode{scalar: yamlString(t)}, nil
+	case json.Number:
+		return &yamlNode{scalar: t.String()}, nil
+	case bool:
+		return &yamlNode{scalar: fmt.Sprint(t)}, nil
+	case nil:
+		return &yamlNode{scalar: "null"}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected json token %v", tok)
+}
+
+func yamlString(s string) string {
+	if rxYAMLPlain.MatchString(s) && !yamlReserved[strings.ToLower(s)] {
+		return s
+	}
+
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// Return inline text of scalar or empty collection, false for block
+func (n *yamlNode) inline() (string, bool) {
+	switch {
+	case n.scalar != "":
+		return n.scalar, true
+	case len(n.items) > 0:
+		return "", false
+	case n.array:
+		return "[]", true
+	}
+
+	return "{}", true
+}
+
+// Return lines of block collection indented by indent spaces
+func (n *yamlNode) lines(indent int) []string {
+	pad := strings.Repeat(" ", indent)
+
+	var out []string
+	for i, item := range n.items {
+		if n.array {
+			if s, ok := item.inline(); ok {
+				out = append(out, pad+"- "+s)
+				continue
+			}
+
+			// First line of item follows "- "
+			sub := item.lines(indent + 2)
+			sub[0] = pad + "- " + sub[0][indent+2:]
+			out = append(out, sub...)
+			continue
+		}
+
+		key := pad + yamlString(n.keys[i]) + ":"
+		if s, ok := item.inline(); ok {
+			out = append(out, key+" "+s)
+			continue
+		}
+
+		out = append(out, key)
+		out = append(out, item.lines(indent+2)...)
+	}
+
+	return out
+}